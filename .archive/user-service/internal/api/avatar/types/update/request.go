@@ -8,21 +8,21 @@ import (
 // Body represents the handler's structured request-body
 type Body struct {
 	types.Helper `json:"-"`
-	Email        string `json:"email" validate:"required,email"` // Email represents the user's required email address.
-	Avatar       string `json:"avatar" validate:"required"`
+	Email        string `json:"email" validate:"omitempty,email"` // Email, if provided, must match the authenticated subject's email address.
+	Avatar       string `json:"avatar" validate:"required,http_url,max=2048"`
 }
 
 func (b *Body) Help() types.Validators {
 	var mapping = types.Validators{
 		"email": {
 			Value:   b.Email,
-			Valid:   b.Email != "",
-			Message: "(Required) A valid, unique email address.",
+			Valid:   true,
+			Message: "(Optional) The authenticated account's email address.",
 		},
 		"avatar": {
 			Value:   b.Avatar,
 			Valid:   b.Avatar != "",
-			Message: "(Required) An avatar URL.",
+			Message: "(Required) An http(s) avatar URL; see \"PUT /users/me/avatar\" for image uploads.",
 		},
 	}
 
@@ -1,11 +1,16 @@
 package avatar
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"log/slog"
 	"net/http"
+	"strings"
 
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/x-ethr/levels"
-	"github.com/x-ethr/pg"
 	"github.com/x-ethr/server"
 	"github.com/x-ethr/server/middleware"
 	"github.com/x-ethr/server/telemetry"
@@ -14,90 +19,95 @@ import (
 	"go.opentelemetry.io/otel/trace"
 
 	"user-service/internal/api/avatar/types/update"
+	"user-service/internal/authorization"
+	"user-service/internal/database"
+	"user-service/internal/events"
+	"user-service/internal/outbox"
 	"user-service/models/users"
 )
 
-var patch server.Handle = func(x *types.CTX) {
-	const name = "avatar-update"
+// missing is returned by the update transaction if the authenticated account doesn't exist.
+var missing = errors.New("account with email address not found")
 
-	ctx := x.Request().Context()
+// assign updates the account's avatar, enqueueing a "user.updated" event within the same transaction.
+func assign(ctx context.Context, pool *pgxpool.Pool, arguments *users.UpdateUserAvatarParams) (user users.User, e error) {
+	e = database.WithTx(ctx, pool, nil, func(tx users.DBTX) error {
+		user, e = users.New().UpdateUserAvatar(ctx, tx, arguments)
+		switch {
+		case errors.Is(e, pgx.ErrNoRows):
+			return missing
+		case e != nil:
+			return fmt.Errorf("unable to update user's avatar: %w", e)
+		}
 
-	labeler := telemetry.Labeler(ctx)
-	service := middleware.New().Service().Value(ctx)
-	ctx, span := trace.SpanFromContext(ctx).TracerProvider().Tracer(service).Start(ctx, name)
+		return outbox.Enqueue(ctx, tx, user.ID, &events.Update{ID: user.ID, Fields: []string{"avatar"}, Modification: user.Modification.Time})
+	})
 
-	defer span.End()
+	return user, e
+}
 
-	generic, e := x.Input()
-	if e != nil {
-		labeler.Add(attribute.Bool("error", true))
-		x.Error(&types.Exception{Code: http.StatusInternalServerError, Message: http.StatusText(http.StatusInternalServerError), Log: "Validator Failed to Hydrate CTX Input"})
-		return
-	}
+func patch(pool *pgxpool.Pool) server.Handle {
+	return func(x *types.CTX) {
+		const name = "avatar-update"
 
-	var input = generic.(*update.Body)
+		ctx := x.Request().Context()
 
-	slog.DebugContext(ctx, "Input", slog.Any("request", input))
+		labeler := telemetry.Labeler(ctx)
+		service := middleware.New().Service().Value(ctx)
+		ctx, span := trace.SpanFromContext(ctx).TracerProvider().Tracer(service).Start(ctx, name)
 
-	dsn := pg.DSN()
-	connection, e := pg.Connection(ctx, dsn)
-	if e != nil {
-		labeler.Add(attribute.Bool("error", true))
-		x.Error(&types.Exception{Code: http.StatusInternalServerError, Log: "Unable to Establish Connection to Database", Source: e})
-		return
-	}
+		defer span.End()
 
-	tx, e := connection.Begin(ctx)
-	if e != nil {
-		pg.Disconnect(ctx, connection, tx)
+		generic, e := x.Input()
+		if e != nil {
+			labeler.Add(attribute.Bool("error", true))
+			x.Error(&types.Exception{Code: http.StatusInternalServerError, Message: http.StatusText(http.StatusInternalServerError), Log: "Validator Failed to Hydrate CTX Input"})
+			return
+		}
 
-		labeler.Add(attribute.Bool("error", true))
-		x.Error(&types.Exception{Code: http.StatusInternalServerError, Log: "Unable to Begin a Database Transaction", Source: e})
-		return
-	}
+		var input = generic.(*update.Body)
 
-	count, e := users.New().Count(ctx, tx, input.Email)
-	if e != nil {
-		pg.Disconnect(ctx, connection, tx)
+		slog.DebugContext(ctx, "Input", slog.Any("request", input))
 
-		labeler.Add(attribute.Bool("error", true))
-		x.Error(&types.Exception{Code: http.StatusInternalServerError, Log: "Unable to Check if User Exist(s)", Source: e})
-		return
-	} else if count == 0 {
-		pg.Disconnect(ctx, connection, tx)
+		// --> the account is always the authenticated subject; a body email is only accepted if it names the same account
+		claims := authorization.Claims(ctx)
+		if input.Email != "" && !(strings.EqualFold(input.Email, claims.Subject)) {
+			labeler.Add(attribute.Bool("error", true))
+			x.Error(&types.Exception{Code: http.StatusForbidden, Message: "Avatar May Only be Updated for the Authenticated Account"})
+			return
+		}
 
-		labeler.Add(attribute.Bool("error", true))
-		x.Error(&types.Exception{Code: http.StatusNotFound, Message: "Account With Email Address Not Found"})
-		return
-	}
+		email := users.NormalizeEmail(claims.Subject)
 
-	arguments := &users.UpdateUserAvatarParams{Email: input.Email, Avatar: &input.Avatar}
-	if e := users.New().UpdateUserAvatar(ctx, tx, arguments); e != nil {
-		pg.Disconnect(ctx, connection, tx)
+		arguments := &users.UpdateUserAvatarParams{Email: email, Avatar: &input.Avatar}
+		_, e = assign(ctx, pool, arguments)
 
-		labeler.Add(attribute.Bool("error", true))
-		x.Error(&types.Exception{Code: http.StatusInternalServerError, Source: e, Log: "Unable to Update User's Avatar"})
-		return
-	}
+		switch {
+		case errors.Is(e, missing):
+			labeler.Add(attribute.Bool("error", true))
+			x.Error(&types.Exception{Code: http.StatusNotFound, Message: "Account With Email Address Not Found"})
+			return
+		case e != nil:
+			labeler.Add(attribute.Bool("error", true))
+			x.Error(&types.Exception{Code: http.StatusInternalServerError, Source: e, Log: "Unable to Update User's Avatar"})
+			return
+		}
 
-	// --> commit the transaction
-	if e := tx.Commit(ctx); e != nil {
-		pg.Disconnect(ctx, connection, tx)
+		slog.Log(ctx, levels.Trace, "Successfully Committed Database Transaction")
+
+		x.Complete(&types.Response{Status: http.StatusOK, Payload: arguments})
 
-		labeler.Add(attribute.Bool("error", true))
-		x.Error(&types.Exception{Code: http.StatusInternalServerError, Source: e, Log: "Unable to Commit Transaction"})
 		return
 	}
+}
 
-	slog.Log(ctx, levels.Trace, "Successfully Committed Database Transaction")
+// Patch constructs the handler, executing its queries against the process-wide connection pool.
+func Patch(pool *pgxpool.Pool) http.HandlerFunc {
+	handler := patch(pool)
 
-	x.Complete(&types.Response{Status: http.StatusOK, Payload: arguments})
+	return func(w http.ResponseWriter, r *http.Request) {
+		server.Validate[update.Body](w, r, update.V, handler)
 
-	return
+		return
+	}
 }
-
-var Patch = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-	server.Validate[update.Body](w, r, update.V, patch)
-
-	return
-})
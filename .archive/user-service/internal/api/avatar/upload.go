@@ -0,0 +1,232 @@
+package avatar
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"mime"
+	"net/http"
+	"path"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/x-ethr/server/middleware"
+	"github.com/x-ethr/server/telemetry"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	"user-service/internal/authorization"
+	"user-service/internal/imaging"
+	"user-service/internal/storage"
+	"user-service/models/users"
+)
+
+// Size is the maximum accepted upload size, in bytes.
+const Size = 5 << 20
+
+// Field is the multipart form field expected to contain the image.
+const Field = "avatar"
+
+var (
+	ErrTooLarge     = fmt.Errorf("upload exceeds %d bytes", Size)
+	ErrMissingField = fmt.Errorf("multipart upload is missing the %q field", Field)
+)
+
+// Response represents a successful upload: the stored "full" rendition - also assigned as the account's avatar - and
+// its thumbnail(s), keyed by size.
+type Response struct {
+	Avatar     string            `json:"avatar"`
+	Thumbnails map[string]string `json:"thumbnails"`
+}
+
+// read returns the uploaded image: either the request's raw body, or the [Field] part of a "multipart/form-data" body.
+func read(w http.ResponseWriter, r *http.Request) ([]byte, error) {
+	r.Body = http.MaxBytesReader(w, r.Body, Size+(64<<10)) // --> allow for multipart framing overhead
+
+	var source io.Reader = r.Body
+	if media, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type")); media == "multipart/form-data" {
+		reader, e := r.MultipartReader()
+		if e != nil {
+			return nil, e
+		}
+
+		for {
+			part, e := reader.NextPart()
+			if errors.Is(e, io.EOF) {
+				return nil, ErrMissingField
+			} else if e != nil {
+				return nil, e
+			}
+
+			if part.FormName() == Field {
+				source = part
+				break
+			}
+		}
+	}
+
+	data, e := io.ReadAll(io.LimitReader(source, Size+1))
+	var exceeded *http.MaxBytesError
+	switch {
+	case errors.As(e, &exceeded), len(data) > Size:
+		return nil, ErrTooLarge
+	case e != nil:
+		return nil, e
+	case len(data) == 0:
+		return nil, errors.New("empty upload")
+	}
+
+	return data, nil
+}
+
+// stale returns the storage key(s) of the previous avatar's renditions, provided it was uploaded to the store - i.e. its
+// URL names a "{user}/{digest}/full{extension}" key under the store's base URL. Avatar(s) assigned via "PATCH /avatar"
+// may reference any URL, and are never deleted.
+func stale(store storage.Storage, id int64, previous *string, current string) []string {
+	if previous == nil || *previous == current {
+		return nil
+	}
+
+	key, ok := strings.CutPrefix(*previous, store.URL(""))
+	if !(ok) {
+		return nil
+	}
+
+	directory, file := path.Split(key)
+	extension := path.Ext(file)
+
+	owner, digest, _ := strings.Cut(strings.TrimSuffix(directory, "/"), "/")
+	if owner != fmt.Sprintf("%d", id) || digest == "" || strings.Contains(digest, "/") || file != "full"+extension {
+		return nil
+	}
+
+	keys := []string{key}
+	for _, size := range imaging.Thumbnails {
+		keys = append(keys, fmt.Sprintf("%s%d%s", directory, size, extension))
+	}
+
+	return keys
+}
+
+// Upload constructs the "PUT /users/me/avatar" handler. The image - a raw body, or a multipart upload's [Field] part - is
+// sniffed, normalized and resized via [imaging.Process]; each rendition is stored under a content-addressed key, so its
+// URL is stable and cacheable indefinitely. The "full" rendition's URL becomes the account's avatar, and the previously
+// uploaded avatar's renditions are deleted.
+func Upload(pool *pgxpool.Pool, store storage.Storage) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const name = "avatar-upload"
+
+		ctx := r.Context()
+
+		labeler := telemetry.Labeler(ctx)
+		service := middleware.New().Service().Value(ctx)
+		ctx, span := trace.SpanFromContext(ctx).TracerProvider().Tracer(service).Start(ctx, name)
+
+		defer span.End()
+
+		data, e := read(w, r)
+		switch {
+		case errors.Is(e, ErrTooLarge):
+			labeler.Add(attribute.Bool("error", true))
+			authorization.Respond(w, r, http.StatusRequestEntityTooLarge, e.Error())
+			return
+		case e != nil:
+			labeler.Add(attribute.Bool("error", true))
+			authorization.Respond(w, r, http.StatusBadRequest, e.Error())
+			return
+		}
+
+		renditions, e := imaging.Process(data)
+		switch {
+		case errors.Is(e, imaging.ErrUnsupportedType):
+			labeler.Add(attribute.Bool("error", true))
+			authorization.Respond(w, r, http.StatusUnsupportedMediaType, e.Error())
+			return
+		case errors.Is(e, imaging.ErrInvalidImage), errors.Is(e, imaging.ErrTooLarge):
+			labeler.Add(attribute.Bool("error", true))
+			authorization.Respond(w, r, http.StatusUnprocessableEntity, e.Error())
+			return
+		case e != nil:
+			slog.ErrorContext(ctx, "Unable to Process Avatar", slog.String("error", e.Error()))
+
+			labeler.Add(attribute.Bool("error", true))
+			authorization.Respond(w, r, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+			return
+		}
+
+		email := users.NormalizeEmail(authorization.Claims(ctx).Subject)
+
+		user, e := users.New().Profile(ctx, pool, email)
+		switch {
+		case errors.Is(e, pgx.ErrNoRows):
+			labeler.Add(attribute.Bool("error", true))
+			authorization.Respond(w, r, http.StatusNotFound, "Account With Email Address Not Found")
+			return
+		case e != nil:
+			slog.ErrorContext(ctx, "Unable to Hydrate User Profile", slog.String("error", e.Error()))
+
+			labeler.Add(attribute.Bool("error", true))
+			authorization.Respond(w, r, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+			return
+		}
+
+		// --> content-addressed by the normalized "full" rendition; re-uploading the same image yields the same URL(s)
+		digest := sha256.Sum256(renditions[0].Data)
+		prefix := fmt.Sprintf("%d/%s", user.ID, hex.EncodeToString(digest[:16]))
+
+		response := &Response{Thumbnails: make(map[string]string, len(renditions)-1)}
+		for _, rendition := range renditions {
+			key := prefix + "/" + rendition.Name + rendition.Extension
+			if e := store.Put(ctx, key, rendition.Data, rendition.ContentType); e != nil {
+				slog.ErrorContext(ctx, "Unable to Store Avatar Rendition", slog.String("key", key), slog.String("error", e.Error()))
+
+				labeler.Add(attribute.Bool("error", true))
+				authorization.Respond(w, r, http.StatusBadGateway, "Unable to Store Avatar")
+				return
+			}
+
+			if rendition.Name == "full" {
+				response.Avatar = store.URL(key)
+			} else {
+				response.Thumbnails[rendition.Name] = store.URL(key)
+			}
+		}
+
+		// --> stored prior to assignment, so the committed URL always resolves
+		_, e = assign(ctx, pool, &users.UpdateUserAvatarParams{Email: email, Avatar: &response.Avatar})
+		switch {
+		case errors.Is(e, missing):
+			labeler.Add(attribute.Bool("error", true))
+			authorization.Respond(w, r, http.StatusNotFound, "Account With Email Address Not Found")
+			return
+		case e != nil:
+			slog.ErrorContext(ctx, "Unable to Assign Avatar", slog.String("error", e.Error()))
+
+			labeler.Add(attribute.Bool("error", true))
+			authorization.Respond(w, r, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+			return
+		}
+
+		slog.InfoContext(ctx, "Uploaded Avatar", slog.Int64("user", user.ID), slog.String("avatar", response.Avatar))
+
+		// --> deleted only once the new avatar is committed; a failure merely leaves unreferenced object(s) behind
+		for _, key := range stale(store, user.ID, user.Avatar, response.Avatar) {
+			if e := store.Delete(ctx, key); e != nil {
+				slog.WarnContext(ctx, "Unable to Delete Previous Avatar Rendition", slog.String("key", key), slog.String("error", e.Error()))
+			}
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Cache-Control", "no-store")
+		w.WriteHeader(http.StatusOK)
+
+		json.NewEncoder(w).Encode(response)
+
+		return
+	}
+}
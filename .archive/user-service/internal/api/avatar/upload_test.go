@@ -0,0 +1,93 @@
+package avatar
+
+import (
+	"bytes"
+	"errors"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+
+	"user-service/internal/storage"
+)
+
+func TestRead(t *testing.T) {
+	raw := httptest.NewRequest(http.MethodPut, "/users/me/avatar", bytes.NewReader([]byte("image")))
+	raw.Header.Set("Content-Type", "image/png")
+
+	data, e := read(httptest.NewRecorder(), raw)
+	if e != nil || string(data) != "image" {
+		t.Errorf("expected \"image\", got %q (error: %v)", data, e)
+	}
+
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	writer.WriteField("description", "ignored")
+	part, _ := writer.CreateFormFile(Field, "avatar.png")
+	part.Write([]byte("multipart image"))
+	writer.Close()
+
+	form := httptest.NewRequest(http.MethodPut, "/users/me/avatar", &body)
+	form.Header.Set("Content-Type", writer.FormDataContentType())
+
+	data, e = read(httptest.NewRecorder(), form)
+	if e != nil || string(data) != "multipart image" {
+		t.Errorf("expected \"multipart image\", got %q (error: %v)", data, e)
+	}
+}
+
+func TestReadInvalid(t *testing.T) {
+	large := httptest.NewRequest(http.MethodPut, "/users/me/avatar", bytes.NewReader(make([]byte, Size+1)))
+	if _, e := read(httptest.NewRecorder(), large); !(errors.Is(e, ErrTooLarge)) {
+		t.Errorf("expected ErrTooLarge, got %v", e)
+	}
+
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	writer.WriteField("description", "no image")
+	writer.Close()
+
+	form := httptest.NewRequest(http.MethodPut, "/users/me/avatar", &body)
+	form.Header.Set("Content-Type", writer.FormDataContentType())
+
+	if _, e := read(httptest.NewRecorder(), form); !(errors.Is(e, ErrMissingField)) {
+		t.Errorf("expected ErrMissingField, got %v", e)
+	}
+
+	empty := httptest.NewRequest(http.MethodPut, "/users/me/avatar", nil)
+	if _, e := read(httptest.NewRecorder(), empty); e == nil {
+		t.Errorf("expected an error for an empty upload")
+	}
+}
+
+func TestStale(t *testing.T) {
+	store := &storage.Filesystem{Directory: t.TempDir(), Base: "http://localhost:8080/v1/users/avatars"}
+
+	current := store.URL("1/0123456789abcdef/full.png")
+
+	pointer := func(value string) *string { return &value }
+
+	expected := []string{"1/fedcba9876543210/full.jpg", "1/fedcba9876543210/256.jpg", "1/fedcba9876543210/128.jpg", "1/fedcba9876543210/64.jpg"}
+	if keys := stale(store, 1, pointer(store.URL("1/fedcba9876543210/full.jpg")), current); !(slices.Equal(keys, expected)) {
+		t.Errorf("expected %v, got %v", expected, keys)
+	}
+
+	tests := map[string]*string{
+		"None":           nil,
+		"Unchanged":      pointer(current),
+		"External":       pointer("https://example.com/avatar.png"),
+		"Another-User":   pointer(store.URL("2/fedcba9876543210/full.png")),
+		"Thumbnail":      pointer(store.URL("1/fedcba9876543210/64.png")),
+		"Nested":         pointer(store.URL("1/fedcba9876543210/nested/full.png")),
+		"Missing-Digest": pointer(store.URL("1/full.png")),
+	}
+
+	for name, previous := range tests {
+		t.Run(name, func(t *testing.T) {
+			if keys := stale(store, 1, previous, current); len(keys) != 0 {
+				t.Errorf("expected no stale key(s), got %v", keys)
+			}
+		})
+	}
+}
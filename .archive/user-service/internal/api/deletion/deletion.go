@@ -0,0 +1,125 @@
+package deletion
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/x-ethr/server/middleware"
+	"github.com/x-ethr/server/telemetry"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	"user-service/internal/authorization"
+	"user-service/internal/database"
+	"user-service/internal/token"
+	"user-service/internal/token/refresh"
+	"user-service/models/users"
+)
+
+// missing is returned by the deletion transaction if the user doesn't exist or was already deleted.
+var missing = errors.New("user not found")
+
+// identifier parses the request's "{id}" path value.
+func identifier(r *http.Request) (int64, error) {
+	id, e := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if e != nil || id <= 0 {
+		return 0, fmt.Errorf("invalid user id: %q", r.PathValue("id"))
+	}
+
+	return id, nil
+}
+
+// Delete constructs the "DELETE /users/{id}" handler, soft-deleting the user and revoking all of its outstanding token(s) -
+// access tokens via the subject's cutoff, and refresh token families via the manager. An account may delete itself; ROOT
+// account(s) may delete any user, as determined by the caller's current account type rather than the token's roles claim.
+// The settings must reflect the lifetime of token(s) issued to user(s) (see [token.RevokeSubject]).
+func Delete(pool *pgxpool.Pool, cutoffs token.Cutoffs, manager *refresh.Manager, settings ...token.Variadic) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const name = "user-deletion"
+
+		ctx := r.Context()
+
+		labeler := telemetry.Labeler(ctx)
+		service := middleware.New().Service().Value(ctx)
+		ctx, span := trace.SpanFromContext(ctx).TracerProvider().Tracer(service).Start(ctx, name)
+
+		defer span.End()
+
+		id, e := identifier(r)
+		if e != nil {
+			labeler.Add(attribute.Bool("error", true))
+			authorization.Respond(w, r, http.StatusBadRequest, e.Error())
+			return
+		}
+
+		claims := authorization.Claims(ctx)
+
+		var user users.User
+		e = database.WithTx(ctx, pool, nil, func(tx users.DBTX) (e error) {
+			user, e = users.New().Attributes(ctx, tx, id)
+			switch {
+			case errors.Is(e, pgx.ErrNoRows):
+				return missing
+			case e != nil:
+				return fmt.Errorf("unable to hydrate user: %w", e)
+			}
+
+			if user.Email != users.NormalizeEmail(claims.Subject) {
+				// --> a token's roles claim outlives a demotion; only the caller's current account type is trusted
+				caller, e := users.New().Profile(ctx, tx, users.NormalizeEmail(claims.Subject))
+				switch {
+				case errors.Is(e, pgx.ErrNoRows):
+					return authorization.ErrNotOwner
+				case e != nil:
+					return fmt.Errorf("unable to hydrate caller's account: %w", e)
+				case caller.AccountType != users.UserAccountTypeROOT:
+					return authorization.ErrNotOwner
+				}
+			}
+
+			if user, e = users.New().SoftDelete(ctx, tx, id); e != nil {
+				return fmt.Errorf("unable to delete user: %w", e)
+			}
+
+			// --> revoke prior to commit; a failed revocation must not leave a deleted account with usable token(s)
+			if e := token.RevokeSubject(ctx, cutoffs, user.Email, settings...); e != nil {
+				return fmt.Errorf("unable to revoke user's token(s): %w", e)
+			}
+
+			// --> otherwise, a refresh token would mint access token(s) issued after the cutoff
+			if e := manager.RevokeSubject(ctx, user.Email); e != nil {
+				return fmt.Errorf("unable to revoke user's refresh token(s): %w", e)
+			}
+
+			return nil
+		})
+
+		switch {
+		case errors.Is(e, missing):
+			labeler.Add(attribute.Bool("error", true))
+			authorization.Respond(w, r, http.StatusNotFound, "User Not Found")
+			return
+		case errors.Is(e, authorization.ErrNotOwner):
+			labeler.Add(attribute.Bool("error", true))
+			authorization.Forbidden(w, r, e.Error())
+			return
+		case e != nil:
+			slog.ErrorContext(ctx, "Unable to Delete User", slog.Int64("user", id), slog.String("error", e.Error()))
+
+			labeler.Add(attribute.Bool("error", true))
+			authorization.Respond(w, r, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+			return
+		}
+
+		slog.InfoContext(ctx, "Deleted User", slog.Int64("user", id), slog.String("subject", claims.Subject))
+
+		w.WriteHeader(http.StatusNoContent)
+
+		return
+	}
+}
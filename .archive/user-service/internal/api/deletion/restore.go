@@ -0,0 +1,104 @@
+package deletion
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/x-ethr/server/middleware"
+	"github.com/x-ethr/server/telemetry"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	"user-service/internal/authorization"
+	"user-service/internal/database"
+	"user-service/internal/retention"
+	"user-service/models/users"
+)
+
+// Restore constructs the "POST /users/{id}/restore" handler, reverting a soft-deletion within the policy's grace period.
+// The user's token(s) revoked upon deletion remain revoked. The caller's account must currently be ROOT; a token's roles
+// claim alone isn't sufficient, as it outlives a demotion.
+func Restore(pool *pgxpool.Pool, policy *retention.Policy) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const name = "user-restoration"
+
+		ctx := r.Context()
+
+		labeler := telemetry.Labeler(ctx)
+		service := middleware.New().Service().Value(ctx)
+		ctx, span := trace.SpanFromContext(ctx).TracerProvider().Tracer(service).Start(ctx, name)
+
+		defer span.End()
+
+		caller, e := users.New().Profile(ctx, pool, users.NormalizeEmail(authorization.Claims(ctx).Subject))
+		switch {
+		case errors.Is(e, pgx.ErrNoRows):
+			labeler.Add(attribute.Bool("error", true))
+			authorization.Forbidden(w, r, "Account Not Found")
+			return
+		case e != nil:
+			slog.ErrorContext(ctx, "Unable to Hydrate Caller's Account", slog.String("error", e.Error()))
+
+			labeler.Add(attribute.Bool("error", true))
+			authorization.Respond(w, r, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+			return
+		case caller.AccountType != users.UserAccountTypeROOT:
+			labeler.Add(attribute.Bool("error", true))
+			authorization.Forbidden(w, r, "ROOT Account Required")
+			return
+		}
+
+		id, e := identifier(r)
+		if e != nil {
+			labeler.Add(attribute.Bool("error", true))
+			authorization.Respond(w, r, http.StatusBadRequest, e.Error())
+			return
+		}
+
+		var user users.User
+		e = database.WithTx(ctx, pool, nil, func(tx users.DBTX) (e error) {
+			user, e = policy.Restore(ctx, tx, id)
+
+			return e
+		})
+
+		switch {
+		case errors.Is(e, retention.ErrNotFound):
+			labeler.Add(attribute.Bool("error", true))
+			authorization.Respond(w, r, http.StatusNotFound, "User Not Found")
+			return
+		case errors.Is(e, retention.ErrNotDeleted):
+			labeler.Add(attribute.Bool("error", true))
+			authorization.Respond(w, r, http.StatusConflict, "User Isn't Deleted")
+			return
+		case errors.Is(e, retention.ErrConflict):
+			labeler.Add(attribute.Bool("error", true))
+			authorization.Respond(w, r, http.StatusConflict, "Email Address Belongs to Another Active Account")
+			return
+		case errors.Is(e, retention.ErrExpired):
+			labeler.Add(attribute.Bool("error", true))
+			authorization.Respond(w, r, http.StatusGone, "Restoration Grace Period Has Elapsed")
+			return
+		case e != nil:
+			slog.ErrorContext(ctx, "Unable to Restore User", slog.Int64("user", id), slog.String("error", e.Error()))
+
+			labeler.Add(attribute.Bool("error", true))
+			authorization.Respond(w, r, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+			return
+		}
+
+		slog.InfoContext(ctx, "Restored User", slog.Int64("user", id))
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Cache-Control", "no-store")
+		w.WriteHeader(http.StatusOK)
+
+		json.NewEncoder(w).Encode(&user)
+
+		return
+	}
+}
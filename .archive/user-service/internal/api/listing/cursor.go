@@ -0,0 +1,46 @@
+package listing
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"user-service/models/users"
+)
+
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// position is the serialized form of an opaque cursor. The sort order is embedded so a cursor can't be replayed against a
+// differently ordered listing, which would silently skip or repeat row(s).
+type position struct {
+	Creation   time.Time `json:"c"`
+	ID         int64     `json:"i"`
+	Descending bool      `json:"d"`
+}
+
+// encode returns the opaque cursor resuming after the user.
+func encode(user *users.User, descending bool) string {
+	serialized, _ := json.Marshal(&position{Creation: user.Creation.Time, ID: user.ID, Descending: descending})
+
+	return base64.RawURLEncoding.EncodeToString(serialized)
+}
+
+// decode parses an opaque cursor produced by [encode] for the same sort order.
+func decode(cursor string, descending bool) (*users.Cursor, error) {
+	serialized, e := base64.RawURLEncoding.DecodeString(cursor)
+	if e != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	var p position
+	if e := json.Unmarshal(serialized, &p); e != nil || p.ID <= 0 || p.Creation.IsZero() {
+		return nil, ErrInvalidCursor
+	}
+
+	if p.Descending != descending {
+		return nil, errors.Join(ErrInvalidCursor, errors.New("cursor was issued for a different sort order"))
+	}
+
+	return &users.Cursor{Creation: p.Creation, ID: p.ID}, nil
+}
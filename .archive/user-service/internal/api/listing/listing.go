@@ -0,0 +1,109 @@
+package listing
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/x-ethr/server/middleware"
+	"github.com/x-ethr/server/telemetry"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	"user-service/internal/authorization"
+	"user-service/internal/database"
+	"user-service/models/users"
+)
+
+// Response represents a page of user(s).
+type Response struct {
+	Users []users.User `json:"users"`
+	Next  string       `json:"next,omitempty"` // Next is the opaque cursor of the following page; empty on the last page.
+}
+
+// Handler constructs the "GET /users" handler: a filterable, keyset-paginated listing of active user(s). The total
+// number of matching user(s) is returned via the "X-Total-Count" header. The caller's account must currently be ROOT; a
+// token's roles claim alone isn't sufficient, as it outlives a demotion.
+func Handler(pool *pgxpool.Pool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const name = "user-listing"
+
+		ctx := r.Context()
+
+		labeler := telemetry.Labeler(ctx)
+		service := middleware.New().Service().Value(ctx)
+		ctx, span := trace.SpanFromContext(ctx).TracerProvider().Tracer(service).Start(ctx, name)
+
+		defer span.End()
+
+		caller, e := users.New().Profile(ctx, pool, users.NormalizeEmail(authorization.Claims(ctx).Subject))
+		switch {
+		case errors.Is(e, pgx.ErrNoRows):
+			labeler.Add(attribute.Bool("error", true))
+			authorization.Forbidden(w, r, "Account Not Found")
+			return
+		case e != nil:
+			slog.ErrorContext(ctx, "Unable to Hydrate Caller's Account", slog.String("error", e.Error()))
+
+			labeler.Add(attribute.Bool("error", true))
+			authorization.Respond(w, r, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+			return
+		case caller.AccountType != users.UserAccountTypeROOT:
+			labeler.Add(attribute.Bool("error", true))
+			authorization.Forbidden(w, r, "ROOT Account Required")
+			return
+		}
+
+		params, e := parse(r.URL.Query())
+		if e != nil {
+			labeler.Add(attribute.Bool("error", true))
+			authorization.Respond(w, r, http.StatusBadRequest, e.Error())
+			return
+		}
+
+		// --> fetch one extra row to determine whether a following page exists
+		limit := params.Limit
+		params.Limit++
+
+		var page []users.User
+		var total int64
+
+		// --> a repeatable-read snapshot keeps the page and its total consistent
+		e = database.WithTx(ctx, pool, &database.TxOptions{Isolation: pgx.RepeatableRead, ReadOnly: true}, func(tx users.DBTX) (e error) {
+			if page, e = users.New().Page(ctx, tx, params); e != nil {
+				return e
+			}
+
+			total, e = users.New().Tally(ctx, tx, &params.Filter)
+
+			return e
+		})
+
+		if e != nil {
+			slog.ErrorContext(ctx, "Unable to List Users", slog.String("error", e.Error()))
+
+			labeler.Add(attribute.Bool("error", true))
+			authorization.Respond(w, r, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+			return
+		}
+
+		response := &Response{Users: page}
+		if len(page) > int(limit) {
+			response.Users = page[:limit]
+			response.Next = encode(&response.Users[limit-1], params.Descending)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Cache-Control", "no-store")
+		w.Header().Set("X-Total-Count", strconv.FormatInt(total, 10))
+		w.WriteHeader(http.StatusOK)
+
+		json.NewEncoder(w).Encode(response)
+
+		return
+	}
+}
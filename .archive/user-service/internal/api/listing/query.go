@@ -0,0 +1,100 @@
+package listing
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+
+	"user-service/models/users"
+)
+
+const (
+	// Default is the page size used when the "limit" parameter is omitted.
+	Default = 25
+
+	// Maximum is the largest accepted "limit" parameter.
+	Maximum = 100
+)
+
+// parse validates the listing's query parameter(s):
+//
+//   - limit: page size, 1 through [Maximum]; defaults to [Default].
+//   - cursor: the opaque "next" cursor of the previous page.
+//   - sort: "creation" (oldest first) or "-creation" (newest first, the default).
+//   - account-type, verification-status: exact enum match(es).
+//   - marketing: "true" or "false".
+//   - created-after, created-before: RFC 3339 timestamps bounding the creation range [after, before).
+func parse(values url.Values) (*users.PageParams, error) {
+	params := &users.PageParams{Limit: Default, Descending: true}
+
+	if value := values.Get("limit"); value != "" {
+		limit, e := strconv.Atoi(value)
+		if e != nil || limit < 1 || limit > Maximum {
+			return nil, fmt.Errorf("limit must be an integer between 1 and %d", Maximum)
+		}
+
+		params.Limit = int32(limit)
+	}
+
+	switch value := values.Get("sort"); value {
+	case "", "-creation":
+	case "creation":
+		params.Descending = false
+	default:
+		return nil, fmt.Errorf("sort must be one of (creation, -creation), received %q", value)
+	}
+
+	if value := values.Get("account-type"); value != "" {
+		if !(users.UserAccountType(value).Valid()) {
+			return nil, fmt.Errorf("account-type must be one of %v, received %q", users.AllUserAccountTypeValues(), value)
+		}
+
+		typ := users.UserAccountType(value)
+		params.AccountType = &typ
+	}
+
+	if value := values.Get("verification-status"); value != "" {
+		if !(users.UserVerificationStatus(value).Valid()) {
+			return nil, fmt.Errorf("verification-status must be one of %v, received %q", users.AllUserVerificationStatusValues(), value)
+		}
+
+		status := users.UserVerificationStatus(value)
+		params.VerificationStatus = &status
+	}
+
+	if value := values.Get("marketing"); value != "" {
+		marketing, e := strconv.ParseBool(value)
+		if e != nil {
+			return nil, fmt.Errorf("marketing must be a boolean, received %q", value)
+		}
+
+		params.Marketing = &marketing
+	}
+
+	for key, target := range map[string]**time.Time{"created-after": &params.After, "created-before": &params.Before} {
+		if value := values.Get(key); value != "" {
+			instant, e := time.Parse(time.RFC3339Nano, value)
+			if e != nil {
+				return nil, fmt.Errorf("%s must be an RFC 3339 timestamp, received %q", key, value)
+			}
+
+			*target = &instant
+		}
+	}
+
+	if params.After != nil && params.Before != nil && !(params.After.Before(*params.Before)) {
+		return nil, fmt.Errorf("created-after must precede created-before")
+	}
+
+	if value := values.Get("cursor"); value != "" {
+		cursor, e := decode(value, params.Descending)
+		if e != nil {
+			return nil, e
+		}
+
+		params.Cursor = cursor
+	}
+
+	return params, nil
+}
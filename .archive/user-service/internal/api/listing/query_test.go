@@ -0,0 +1,99 @@
+package listing
+
+import (
+	"errors"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"user-service/models/users"
+)
+
+func TestParse(t *testing.T) {
+	values := url.Values{
+		"limit":               {"10"},
+		"sort":                {"creation"},
+		"account-type":        {"ROOT"},
+		"verification-status": {"PENDING"},
+		"marketing":           {"false"},
+		"created-after":       {"2024-01-01T00:00:00Z"},
+		"created-before":      {"2024-02-01T00:00:00Z"},
+	}
+
+	params, e := parse(values)
+	if e != nil {
+		t.Fatalf("unexpected error: %v", e)
+	}
+
+	switch {
+	case params.Limit != 10:
+		t.Errorf("expected limit 10, got %d", params.Limit)
+	case params.Descending:
+		t.Errorf("expected ascending order")
+	case params.AccountType == nil || *params.AccountType != users.UserAccountTypeROOT:
+		t.Errorf("expected account-type ROOT, got %v", params.AccountType)
+	case params.VerificationStatus == nil || *params.VerificationStatus != users.UserVerificationStatusPENDING:
+		t.Errorf("expected verification-status PENDING, got %v", params.VerificationStatus)
+	case params.Marketing == nil || *params.Marketing:
+		t.Errorf("expected marketing false, got %v", params.Marketing)
+	case params.After == nil || params.Before == nil || params.Before.Sub(*params.After) != 31*24*time.Hour:
+		t.Errorf("unexpected creation range: %v - %v", params.After, params.Before)
+	}
+}
+
+func TestParseDefaults(t *testing.T) {
+	params, e := parse(url.Values{})
+	if e != nil {
+		t.Fatalf("unexpected error: %v", e)
+	}
+
+	if params.Limit != Default || !(params.Descending) || params.Cursor != nil || params.AccountType != nil || params.Marketing != nil {
+		t.Errorf("unexpected defaults: %+v", params)
+	}
+}
+
+func TestParseInvalid(t *testing.T) {
+	tests := []url.Values{
+		{"limit": {"0"}},
+		{"limit": {"101"}},
+		{"sort": {"email"}},
+		{"account-type": {"ADMIN"}},
+		{"verification-status": {"verified"}},
+		{"marketing": {"maybe"}},
+		{"created-after": {"yesterday"}},
+		{"created-after": {"2024-02-01T00:00:00Z"}, "created-before": {"2024-01-01T00:00:00Z"}},
+		{"cursor": {"not a cursor"}},
+	}
+
+	for _, values := range tests {
+		if _, e := parse(values); e == nil {
+			t.Errorf("expected an error for %v", values)
+		}
+	}
+}
+
+func TestCursor(t *testing.T) {
+	user := &users.User{ID: 42, Creation: pgtype.Timestamptz{Time: time.Date(2024, 1, 2, 3, 4, 5, 123456000, time.UTC), Valid: true}}
+
+	cursor := encode(user, true)
+
+	position, e := decode(cursor, true)
+	if e != nil {
+		t.Fatalf("unexpected error: %v", e)
+	}
+
+	if position.ID != user.ID || !(position.Creation.Equal(user.Creation.Time)) {
+		t.Errorf("expected %v/%d, got %v/%d", user.Creation.Time, user.ID, position.Creation, position.ID)
+	}
+
+	if _, e := decode(cursor, false); !(errors.Is(e, ErrInvalidCursor)) {
+		t.Errorf("expected ErrInvalidCursor for a mismatched sort order, got %v", e)
+	}
+
+	params, e := parse(url.Values{"cursor": {cursor}})
+	if e != nil || params.Cursor == nil || params.Cursor.ID != user.ID {
+		t.Errorf("expected the cursor to round-trip through parse, got %+v (error: %v)", params, e)
+	}
+}
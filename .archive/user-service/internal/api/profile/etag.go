@@ -0,0 +1,36 @@
+package profile
+
+import (
+	"strconv"
+	"strings"
+
+	"user-service/models/users"
+)
+
+// etag derives the user's strong entity tag from its last modification - or, if never modified, its creation - timestamp.
+func etag(user *users.User) string {
+	version := user.Creation.Time
+	if user.Modification.Valid {
+		version = user.Modification.Time
+	}
+
+	return strconv.Quote(strconv.FormatInt(version.UnixMicro(), 36))
+}
+
+// matches evaluates an "If-Match" or "If-None-Match" header's entity tag list against the current tag. "If-Match" requires
+// the strong comparison function, whereas "If-None-Match" uses the weak one (RFC 9110, section 8.8.3.2). "*" matches any
+// current representation.
+func matches(header, current string, weak bool) bool {
+	for _, tag := range strings.Split(header, ",") {
+		tag = strings.TrimSpace(tag)
+		if weak {
+			tag = strings.TrimPrefix(tag, "W/")
+		}
+
+		if tag == "*" || tag == current {
+			return true
+		}
+	}
+
+	return false
+}
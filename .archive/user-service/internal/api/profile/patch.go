@@ -0,0 +1,137 @@
+package profile
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	"user-service/models/users"
+)
+
+// ErrInvalidPatch is returned by [parse] for a malformed or invalid merge patch document.
+var ErrInvalidPatch = errors.New("invalid merge patch")
+
+// Length is the maximum length, in character(s), of the "name" and "display-name" attribute(s).
+const Length = 255
+
+// optional represents a merge patch member: absent (Set == false), null (Value == nil), or a replacement value.
+type optional[T any] struct {
+	Set   bool
+	Value *T
+}
+
+// Merge represents a validated RFC 7396 JSON merge patch of the mutable profile attribute(s).
+type Merge struct {
+	Name        optional[string]
+	DisplayName optional[string]
+	Username    optional[string]
+	Marketing   optional[bool]
+}
+
+// parse decodes and validates a merge patch document. Unknown or read-only member(s) are rejected rather than ignored.
+func parse(body []byte) (*Merge, error) {
+	var document map[string]json.RawMessage
+	if e := json.Unmarshal(body, &document); e != nil || document == nil {
+		return nil, fmt.Errorf("%w: document must be a json object", ErrInvalidPatch)
+	}
+
+	var patch Merge
+	for key, value := range document {
+		var e error
+		switch key {
+		case "name":
+			patch.Name, e = text(value)
+		case "display-name":
+			patch.DisplayName, e = text(value)
+		case "username":
+			if patch.Username, e = decode[string](value); e == nil && patch.Username.Value != nil {
+				*(patch.Username.Value) = users.NormalizeUsername(*(patch.Username.Value))
+				if !(users.ValidUsername(*(patch.Username.Value))) {
+					e = errors.New("must be 3 to 32 alphanumeric, \".\", \"_\" or \"-\" character(s), starting with an alphanumeric")
+				}
+			}
+		case "marketing":
+			if patch.Marketing, e = decode[bool](value); e == nil && patch.Marketing.Value == nil {
+				e = errors.New("can't be null")
+			}
+		default:
+			e = errors.New("unknown or read-only attribute")
+		}
+
+		if e != nil {
+			return nil, fmt.Errorf("%w: %q %s", ErrInvalidPatch, key, e.Error())
+		}
+	}
+
+	return &patch, nil
+}
+
+// decode unmarshals a merge patch member's value, where null removes the attribute.
+func decode[T any](value json.RawMessage) (optional[T], error) {
+	if bytes.Equal(bytes.TrimSpace(value), []byte("null")) {
+		return optional[T]{Set: true}, nil
+	}
+
+	var v T
+	if e := json.Unmarshal(value, &v); e != nil {
+		return optional[T]{}, fmt.Errorf("must be of type %T", v)
+	}
+
+	return optional[T]{Set: true, Value: &v}, nil
+}
+
+// text decodes a nullable free-text member, trimmed of surrounding whitespace. A blank value removes the attribute.
+func text(value json.RawMessage) (optional[string], error) {
+	member, e := decode[string](value)
+	if e != nil || member.Value == nil {
+		return member, e
+	}
+
+	trimmed := strings.TrimSpace(*(member.Value))
+	switch {
+	case trimmed == "":
+		return optional[string]{Set: true}, nil
+	case utf8.RuneCountInString(trimmed) > Length:
+		return optional[string]{}, fmt.Errorf("exceeds %d character(s)", Length)
+	}
+
+	return optional[string]{Set: true, Value: &trimmed}, nil
+}
+
+// apply merges the patch into the user, returning the sorted json name(s) of the attribute(s) whose value changed.
+func (p *Merge) apply(user *users.User) []string {
+	var changed []string
+
+	merge := func(name string, member optional[string], target **string) {
+		if member.Set && !(equal(*target, member.Value)) {
+			*target = member.Value
+			changed = append(changed, name)
+		}
+	}
+
+	merge("name", p.Name, &user.Name)
+	merge("display-name", p.DisplayName, &user.DisplayName)
+	merge("username", p.Username, &user.Username)
+
+	if p.Marketing.Set && *(p.Marketing.Value) != user.Marketing {
+		user.Marketing = *(p.Marketing.Value)
+		changed = append(changed, "marketing")
+	}
+
+	slices.Sort(changed)
+
+	return changed
+}
+
+// equal compares two nullable string(s) by value.
+func equal(a, b *string) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+
+	return *a == *b
+}
@@ -0,0 +1,89 @@
+package profile
+
+import (
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"user-service/models/users"
+)
+
+func TestPatch(t *testing.T) {
+	name, username := "Jane", "jane"
+	user := &users.User{Name: &name, Username: &username, Marketing: true}
+
+	patch, e := parse([]byte(`{"name": null, "display-name": "  Jane Doe ", "username": " JANE ", "marketing": false}`))
+	if e != nil {
+		t.Fatalf("unexpected error: %v", e)
+	}
+
+	changed := patch.apply(user)
+	if expected := []string{"display-name", "marketing", "name"}; !(slices.Equal(changed, expected)) {
+		t.Errorf("expected changed fields %v, got %v", expected, changed)
+	}
+
+	switch {
+	case user.Name != nil:
+		t.Errorf("expected name to be removed, got %q", *user.Name)
+	case user.DisplayName == nil || *user.DisplayName != "Jane Doe":
+		t.Errorf("expected display-name \"Jane Doe\", got %v", user.DisplayName)
+	case user.Username == nil || *user.Username != "jane":
+		t.Errorf("expected username \"jane\", got %v", user.Username)
+	case user.Marketing:
+		t.Errorf("expected marketing to be false")
+	}
+
+	if changed := patch.apply(user); len(changed) != 0 {
+		t.Errorf("expected a repeated patch to change nothing, got %v", changed)
+	}
+}
+
+func TestPatchInvalid(t *testing.T) {
+	tests := []string{
+		`[]`,
+		`null`,
+		`{"email": "jane@example.com"}`,
+		`{"id": 1}`,
+		`{"marketing": null}`,
+		`{"marketing": "yes"}`,
+		`{"username": "a"}`,
+		`{"username": "jane doe"}`,
+		`{"name": 42}`,
+	}
+
+	for _, body := range tests {
+		if _, e := parse([]byte(body)); !(errors.Is(e, ErrInvalidPatch)) {
+			t.Errorf("expected ErrInvalidPatch for %s, got %v", body, e)
+		}
+	}
+}
+
+func TestETag(t *testing.T) {
+	creation := time.Date(2024, 1, 2, 3, 4, 5, 6000, time.UTC)
+	user := &users.User{Creation: pgtype.Timestamptz{Time: creation, Valid: true}}
+
+	original := etag(user)
+
+	user.Modification = pgtype.Timestamptz{Time: creation.Add(time.Microsecond), Valid: true}
+
+	current := etag(user)
+	if original == current {
+		t.Fatalf("expected the entity tag to change upon modification")
+	}
+
+	switch {
+	case matches(original, current, false):
+		t.Errorf("expected a stale entity tag not to match")
+	case !(matches(original+", "+current, current, false)):
+		t.Errorf("expected an entity tag list containing the current tag to match")
+	case !(matches("*", current, false)):
+		t.Errorf("expected \"*\" to match")
+	case matches("W/"+current, current, false):
+		t.Errorf("expected a weak entity tag not to match strongly")
+	case !(matches("W/"+current, current, true)):
+		t.Errorf("expected a weak entity tag to match weakly")
+	}
+}
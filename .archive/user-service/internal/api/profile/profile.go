@@ -0,0 +1,195 @@
+package profile
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"mime"
+	"net/http"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/x-ethr/server/middleware"
+	"github.com/x-ethr/server/telemetry"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	"user-service/internal/authorization"
+	"user-service/internal/database"
+	"user-service/internal/events"
+	"user-service/internal/outbox"
+	"user-service/models/users"
+)
+
+// Size is the maximum accepted merge patch document size, in bytes.
+const Size = 64 << 10
+
+var (
+	// missing is returned by the update transaction if the authenticated account doesn't exist.
+	missing = errors.New("account with email address not found")
+
+	// stale is returned by the update transaction if the "If-Match" precondition doesn't match the current entity tag.
+	stale = errors.New("account was modified since it was retrieved")
+
+	// taken is returned by the update transaction if the username belongs to another user.
+	taken = errors.New("username is already taken")
+)
+
+// write responds with the user's representation and entity tag.
+func write(w http.ResponseWriter, user *users.User) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("ETag", etag(user))
+	w.WriteHeader(http.StatusOK)
+
+	json.NewEncoder(w).Encode(user)
+}
+
+// Get constructs the "GET /users/me" handler, returning the authenticated account along with an "ETag" header for use
+// as the [Patch] handler's "If-Match" precondition.
+func Get(pool *pgxpool.Pool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const name = "user-profile"
+
+		ctx := r.Context()
+
+		labeler := telemetry.Labeler(ctx)
+		service := middleware.New().Service().Value(ctx)
+		ctx, span := trace.SpanFromContext(ctx).TracerProvider().Tracer(service).Start(ctx, name)
+
+		defer span.End()
+
+		email := users.NormalizeEmail(authorization.Claims(ctx).Subject)
+
+		user, e := users.New().Profile(ctx, pool, email)
+		switch {
+		case errors.Is(e, pgx.ErrNoRows):
+			labeler.Add(attribute.Bool("error", true))
+			authorization.Respond(w, r, http.StatusNotFound, "Account With Email Address Not Found")
+			return
+		case e != nil:
+			slog.ErrorContext(ctx, "Unable to Hydrate User Profile", slog.String("error", e.Error()))
+
+			labeler.Add(attribute.Bool("error", true))
+			authorization.Respond(w, r, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+			return
+		}
+
+		if header := r.Header.Get("If-None-Match"); header != "" && matches(header, etag(&user), true) {
+			w.Header().Set("ETag", etag(&user))
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+
+		write(w, &user)
+
+		return
+	}
+}
+
+// Patch constructs the "PATCH /users/me" handler, applying an RFC 7396 JSON merge patch to the authenticated account's
+// profile. The request must carry an "If-Match" precondition; a stale entity tag is rejected with 412 rather than
+// overwriting a concurrent update. Changed attribute(s) are enqueued as a "user.updated" event.
+func Patch(pool *pgxpool.Pool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const name = "user-profile-update"
+
+		ctx := r.Context()
+
+		labeler := telemetry.Labeler(ctx)
+		service := middleware.New().Service().Value(ctx)
+		ctx, span := trace.SpanFromContext(ctx).TracerProvider().Tracer(service).Start(ctx, name)
+
+		defer span.End()
+
+		precondition := r.Header.Get("If-Match")
+		if precondition == "" {
+			labeler.Add(attribute.Bool("error", true))
+			authorization.Respond(w, r, http.StatusPreconditionRequired, "Missing If-Match Header")
+			return
+		}
+
+		if media, _, e := mime.ParseMediaType(r.Header.Get("Content-Type")); e != nil || (media != "application/merge-patch+json" && media != "application/json") {
+			labeler.Add(attribute.Bool("error", true))
+			authorization.Respond(w, r, http.StatusUnsupportedMediaType, "Content-Type Must be application/merge-patch+json")
+			return
+		}
+
+		body, e := io.ReadAll(http.MaxBytesReader(w, r.Body, Size))
+		if e != nil {
+			labeler.Add(attribute.Bool("error", true))
+			authorization.Respond(w, r, http.StatusRequestEntityTooLarge, fmt.Sprintf("Request Body Exceeds %d Bytes", Size))
+			return
+		}
+
+		patch, e := parse(body)
+		if e != nil {
+			labeler.Add(attribute.Bool("error", true))
+			authorization.Respond(w, r, http.StatusBadRequest, e.Error())
+			return
+		}
+
+		email := users.NormalizeEmail(authorization.Claims(ctx).Subject)
+
+		var user users.User
+		var changed []string
+		e = database.WithTx(ctx, pool, nil, func(tx users.DBTX) (e error) {
+			user, e = users.New().LockProfile(ctx, tx, email)
+			switch {
+			case errors.Is(e, pgx.ErrNoRows):
+				return missing
+			case e != nil:
+				return fmt.Errorf("unable to hydrate user: %w", e)
+			case !(matches(precondition, etag(&user), false)):
+				return stale
+			}
+
+			if changed = patch.apply(&user); len(changed) == 0 {
+				return nil
+			}
+
+			arguments := &users.UpdateProfileParams{ID: user.ID, Name: user.Name, DisplayName: user.DisplayName, Username: user.Username, Marketing: user.Marketing}
+
+			user, e = users.New().UpdateProfile(ctx, tx, arguments)
+			switch {
+			case database.Unique(e, users.UsernameUniqueConstraint):
+				return taken
+			case e != nil:
+				return fmt.Errorf("unable to update user's profile: %w", e)
+			}
+
+			return outbox.Enqueue(ctx, tx, user.ID, &events.Update{ID: user.ID, Fields: changed, Modification: user.Modification.Time})
+		})
+
+		switch {
+		case errors.Is(e, missing):
+			labeler.Add(attribute.Bool("error", true))
+			authorization.Respond(w, r, http.StatusNotFound, "Account With Email Address Not Found")
+			return
+		case errors.Is(e, stale):
+			labeler.Add(attribute.Bool("error", true))
+			authorization.Respond(w, r, http.StatusPreconditionFailed, "Account Was Modified; Retrieve the Latest Version and Retry")
+			return
+		case errors.Is(e, taken):
+			labeler.Add(attribute.Bool("error", true))
+			authorization.Respond(w, r, http.StatusConflict, "Username Is Already Taken")
+			return
+		case e != nil:
+			slog.ErrorContext(ctx, "Unable to Update User Profile", slog.String("error", e.Error()))
+
+			labeler.Add(attribute.Bool("error", true))
+			authorization.Respond(w, r, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+			return
+		}
+
+		if len(changed) > 0 {
+			slog.InfoContext(ctx, "Updated User Profile", slog.Int64("user", user.ID), slog.Any("fields", changed))
+		}
+
+		write(w, &user)
+
+		return
+	}
+}
@@ -0,0 +1,44 @@
+package registration
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"user-service/internal/database"
+	"user-service/internal/events"
+	"user-service/internal/outbox"
+	"user-service/internal/verification"
+	"user-service/models/users"
+)
+
+// exists is returned by [register] if an active account with the email address already exists.
+var exists = errors.New("account with email address already exists")
+
+// register creates the user, issues its verification token and enqueues the "registration" event, returning the verification
+// link. Uniqueness is enforced by the database's [users.EmailUniqueIndex] rather than a preceding existence check, which
+// concurrent registration(s) could both pass.
+func register(ctx context.Context, pool *pgxpool.Pool, verifier *verification.Verifier, input *Body) (result users.User, link string, e error) {
+	e = database.WithTx(ctx, pool, nil, func(tx users.DBTX) error {
+		result, e = users.New().Create(ctx, tx, &users.CreateParams{Email: users.NormalizeEmail(input.Email), Avatar: input.Avatar})
+		switch {
+		case database.Unique(e, users.EmailUniqueIndex):
+			return exists
+		case e != nil:
+			return fmt.Errorf("unable to create new user: %w", e)
+		}
+
+		if link, e = verifier.Issue(ctx, tx, &result); e != nil {
+			return e
+		}
+
+		// --> enqueued within the transaction: the event is published if, and only if, the user is committed
+		event := &events.Registration{ID: result.ID, Email: result.Email, Avatar: result.Avatar, Creation: result.Creation.Time, Verification: link}
+
+		return outbox.Enqueue(ctx, tx, result.ID, event)
+	})
+
+	return result, link, e
+}
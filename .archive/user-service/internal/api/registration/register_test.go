@@ -0,0 +1,156 @@
+package registration
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"user-service/internal/database"
+	"user-service/internal/migration"
+	"user-service/internal/token"
+	"user-service/internal/token/refresh"
+	"user-service/internal/verification"
+	"user-service/models/users"
+)
+
+// TestRegisterConcurrent requires a disposable PostgreSQL database, specified by the "DATABASE_TEST_DSN" environment variable.
+func TestRegisterConcurrent(t *testing.T) {
+	dsn := os.Getenv("DATABASE_TEST_DSN")
+	if dsn == "" {
+		t.Skip("DATABASE_TEST_DSN not set")
+	}
+
+	const concurrency = 16
+
+	ctx := context.Background()
+
+	pool, e := database.Pool(ctx, database.DSN(dsn), database.Connections(1, concurrency))
+	if e != nil {
+		t.Fatalf("unexpected error: %v", e)
+	}
+
+	defer pool.Close()
+
+	runner, e := migration.New(pool, users.Migrations)
+	if e != nil {
+		t.Fatalf("unexpected error: %v", e)
+	}
+
+	if _, e := runner.Up(ctx); e != nil {
+		t.Fatalf("unexpected error: %v", e)
+	}
+
+	private, e := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if e != nil {
+		t.Fatalf("unexpected error: %v", e)
+	}
+
+	token.Configure(private, nil)
+
+	verifier := verification.New()
+
+	email := fmt.Sprintf("concurrent-%d@example.com", time.Now().UnixNano())
+
+	t.Cleanup(func() {
+		if _, e := pool.Exec(context.Background(), `DELETE FROM "User" WHERE (email) = $1`, email); e != nil {
+			t.Errorf("unable to remove test user: %v", e)
+		}
+	})
+
+	var (
+		start = make(chan struct{})
+		group sync.WaitGroup
+
+		mutex     sync.Mutex
+		created   int
+		conflicts int
+		failures  []error
+	)
+
+	for index := 0; index < concurrency; index++ {
+		group.Add(1)
+
+		// --> vary casing and whitespace; all variant(s) normalize to the same address
+		input := &Body{Email: email}
+		if index%2 == 1 {
+			input.Email = strings.ToUpper(input.Email)
+		}
+
+		if index%3 == 0 {
+			input.Email = fmt.Sprintf("  %s ", input.Email)
+		}
+
+		go func() {
+			defer group.Done()
+
+			<-start
+
+			_, _, e := register(ctx, pool, verifier, input)
+
+			mutex.Lock()
+			defer mutex.Unlock()
+
+			switch {
+			case e == nil:
+				created++
+			case errors.Is(e, exists):
+				conflicts++
+			default:
+				failures = append(failures, e)
+			}
+		}()
+	}
+
+	close(start)
+	group.Wait()
+
+	if len(failures) > 0 {
+		t.Fatalf("unexpected errors: %v", errors.Join(failures...))
+	}
+
+	if created != 1 || conflicts != concurrency-1 {
+		t.Errorf("expected 1 created and %d conflicts, got %d created and %d conflicts", concurrency-1, created, conflicts)
+	}
+
+	count, e := users.New().Count(ctx, pool, email)
+	if e != nil {
+		t.Fatalf("unexpected error: %v", e)
+	}
+
+	if count != 1 {
+		t.Errorf("expected exactly 1 account, got %d", count)
+	}
+}
+
+func TestResponse(t *testing.T) {
+	response := &Response{User: users.User{ID: 1, Email: "user@example.com"}, Tokens: &refresh.Pair{Access: "access", Refresh: "refresh", Type: "Bearer"}}
+
+	data, e := json.Marshal(response)
+	if e != nil {
+		t.Fatalf("unexpected error: %v", e)
+	}
+
+	var decoded map[string]interface{}
+	if e := json.Unmarshal(data, &decoded); e != nil {
+		t.Fatalf("unexpected error: %v", e)
+	}
+
+	// --> the user's field(s) remain top-level, so existing client(s) are unaffected
+	if decoded["email"] != "user@example.com" {
+		t.Errorf("expected a top-level email, got %v", decoded["email"])
+	}
+
+	tokens, ok := decoded["tokens"].(map[string]interface{})
+	if !(ok) || tokens["refresh-token"] != "refresh" || tokens["access-token"] != "access" {
+		t.Errorf("unexpected tokens: %v", decoded["tokens"])
+	}
+}
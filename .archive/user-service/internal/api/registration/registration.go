@@ -1,11 +1,12 @@
 package registration
 
 import (
+	"errors"
 	"log/slog"
 	"net/http"
 
+	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/x-ethr/levels"
-	"github.com/x-ethr/pg"
 	"github.com/x-ethr/server"
 	"github.com/x-ethr/server/middleware"
 	"github.com/x-ethr/server/telemetry"
@@ -13,88 +14,84 @@ import (
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/trace"
 
+	"user-service/internal/mail"
+	"user-service/internal/token"
+	"user-service/internal/token/refresh"
+	"user-service/internal/verification"
 	"user-service/models/users"
 )
 
-var handle server.Handle = func(x *types.CTX) {
-	const name = "registration"
+// Response represents a successful registration: the created user, and the session's access + refresh token pair.
+type Response struct {
+	users.User
 
-	ctx := x.Request().Context()
+	Tokens *refresh.Pair `json:"tokens,omitempty"` // Tokens is omitted if issuing the pair failed; the account exists regardless.
+}
 
-	labeler := telemetry.Labeler(ctx)
-	service := middleware.New().Service().Value(ctx)
-	ctx, span := trace.SpanFromContext(ctx).TracerProvider().Tracer(service).Start(ctx, name)
+func handle(pool *pgxpool.Pool, verifier *verification.Verifier, sender mail.Sender, manager *refresh.Manager) server.Handle {
+	return func(x *types.CTX) {
+		const name = "registration"
 
-	defer span.End()
+		ctx := x.Request().Context()
 
-	generic, e := x.Input()
-	if e != nil {
-		labeler.Add(attribute.Bool("error", true))
-		x.Error(&types.Exception{Code: http.StatusInternalServerError, Message: http.StatusText(http.StatusInternalServerError), Log: "Validator Failed to Hydrate CTX Input"})
-		return
-	}
+		labeler := telemetry.Labeler(ctx)
+		service := middleware.New().Service().Value(ctx)
+		ctx, span := trace.SpanFromContext(ctx).TracerProvider().Tracer(service).Start(ctx, name)
 
-	var input = generic.(*Body)
+		defer span.End()
 
-	dsn := pg.DSN()
-	connection, e := pg.Connection(ctx, dsn)
-	if e != nil {
-		labeler.Add(attribute.Bool("error", true))
-		x.Error(&types.Exception{Code: http.StatusInternalServerError, Log: "Unable to Establish Connection to Database", Source: e})
-		return
-	}
+		generic, e := x.Input()
+		if e != nil {
+			labeler.Add(attribute.Bool("error", true))
+			x.Error(&types.Exception{Code: http.StatusInternalServerError, Message: http.StatusText(http.StatusInternalServerError), Log: "Validator Failed to Hydrate CTX Input"})
+			return
+		}
 
-	tx, e := connection.Begin(ctx)
-	if e != nil {
-		pg.Disconnect(ctx, connection, tx)
+		var input = generic.(*Body)
 
-		labeler.Add(attribute.Bool("error", true))
-		x.Error(&types.Exception{Code: http.StatusInternalServerError, Log: "Unable to Begin a Database Transaction", Source: e})
-		return
-	}
+		result, link, e := register(ctx, pool, verifier, input)
 
-	count, e := users.New().Count(ctx, tx, input.Email)
-	if e != nil {
-		pg.Disconnect(ctx, connection, tx)
+		switch {
+		case errors.Is(e, exists):
+			labeler.Add(attribute.Bool("error", true))
+			x.Error(&types.Exception{Code: http.StatusConflict, Message: "Account With Email Address Already Exists"})
+			return
+		case e != nil:
+			labeler.Add(attribute.Bool("error", true))
+			x.Error(&types.Exception{Code: http.StatusInternalServerError, Source: e, Log: "Unable to Register User"})
+			return
+		}
 
-		labeler.Add(attribute.Bool("error", true))
-		x.Error(&types.Exception{Code: http.StatusInternalServerError, Log: "Unable to Check if User Exist(s)", Source: e})
-		return
-	} else if count >= 1 {
-		pg.Disconnect(ctx, connection, tx)
+		slog.Log(ctx, levels.Trace, "Successfully Committed Database Transaction")
 
-		labeler.Add(attribute.Bool("error", true))
-		x.Error(&types.Exception{Code: http.StatusConflict, Message: "Account With Email Address Already Exists"})
-		return
-	}
+		// --> the account exists regardless; a failure past this point is logged rather than failing the registration
+		if e := sender.Send(ctx, verifier.Message(result.Email, link)); e != nil {
+			slog.ErrorContext(ctx, "Unable to Send Verification Email", slog.Int64("user", result.ID), slog.String("error", e.Error()))
+		}
 
-	result, e := users.New().Create(ctx, tx, &users.CreateParams{Email: input.Email, Avatar: input.Avatar})
-	if e != nil {
-		pg.Disconnect(ctx, connection, tx)
+		response := &Response{User: result}
 
-		labeler.Add(attribute.Bool("error", true))
-		x.Error(&types.Exception{Code: http.StatusInternalServerError, Source: e, Log: "Unable to Create New User"})
-		return
-	}
+		// --> the session starts at registration, so the client needn't re-register to remain authenticated
+		claims := &token.Claims{Subject: result.Email, Issuer: "user-service", Roles: []string{string(result.AccountType)}}
+		if response.Tokens, e = manager.Issue(ctx, claims); e != nil {
+			slog.ErrorContext(ctx, "Unable to Issue Session Token(s)", slog.Int64("user", result.ID), slog.String("error", e.Error()))
+		}
 
-	// --> commit the transaction
-	if e := tx.Commit(ctx); e != nil {
-		pg.Disconnect(ctx, connection, tx)
+		x.Complete(&types.Response{Status: http.StatusCreated, Payload: response})
 
-		labeler.Add(attribute.Bool("error", true))
-		x.Error(&types.Exception{Code: http.StatusInternalServerError, Source: e, Log: "Unable to Commit Transaction"})
 		return
 	}
+}
 
-	slog.Log(ctx, levels.Trace, "Successfully Committed Database Transaction")
+// Handler constructs the handler, executing its queries against the process-wide connection pool. Upon registration, a
+// verification link is enqueued with the "registration" event and emailed via the sender, and the response includes the
+// new user's access + refresh token pair issued via the manager.
+func Handler(pool *pgxpool.Pool, verifier *verification.Verifier, sender mail.Sender, manager *refresh.Manager) http.HandlerFunc {
+	handler := handle(pool, verifier, sender, manager)
 
-	x.Complete(&types.Response{Status: http.StatusCreated, Payload: result})
+	return func(w http.ResponseWriter, r *http.Request) {
+		server.Validate[Body](w, r, v, handler)
 
-	return
+		return
+	}
 }
-
-var Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-	server.Validate[Body](w, r, v, handle)
-
-	return
-})
@@ -1,8 +1,12 @@
 package registration
 
 import (
+	"encoding/json"
+
 	"github.com/go-playground/validator/v10"
 	"github.com/x-ethr/server/types"
+
+	"user-service/models/users"
 )
 
 // Body represents the handler's structured request-body
@@ -12,6 +16,19 @@ type Body struct {
 	Avatar       *string `json:"avatar"`
 }
 
+// UnmarshalJSON normalizes the email address prior to validation; see [users.NormalizeEmail].
+func (b *Body) UnmarshalJSON(data []byte) error {
+	type body Body // --> avoids recursion
+
+	if e := json.Unmarshal(data, (*body)(b)); e != nil {
+		return e
+	}
+
+	b.Email = users.NormalizeEmail(b.Email)
+
+	return nil
+}
+
 func (b *Body) Help() types.Validators {
 	var mapping = types.Validators{
 		"email": {
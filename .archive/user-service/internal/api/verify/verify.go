@@ -0,0 +1,85 @@
+package verify
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/x-ethr/server/middleware"
+	"github.com/x-ethr/server/telemetry"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	"user-service/internal/authorization"
+	"user-service/internal/database"
+	"user-service/internal/verification"
+	"user-service/models/users"
+)
+
+// Response represents a successful verification.
+type Response struct {
+	ID                 int64                        `json:"id"`
+	VerificationStatus users.UserVerificationStatus `json:"verification-status"`
+}
+
+// Handler constructs the "GET /verify?token=" handler, redeeming the emailed verification token.
+func Handler(pool *pgxpool.Pool, verifier *verification.Verifier) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const name = "verify"
+
+		ctx := r.Context()
+
+		labeler := telemetry.Labeler(ctx)
+		service := middleware.New().Service().Value(ctx)
+		ctx, span := trace.SpanFromContext(ctx).TracerProvider().Tracer(service).Start(ctx, name)
+
+		defer span.End()
+
+		raw := r.URL.Query().Get("token")
+		if raw == "" {
+			labeler.Add(attribute.Bool("error", true))
+			authorization.Respond(w, r, http.StatusBadRequest, "Missing Verification Token")
+			return
+		}
+
+		var user int64
+		e := database.WithTx(ctx, pool, nil, func(tx users.DBTX) (e error) {
+			user, e = verifier.Redeem(ctx, tx, raw)
+
+			return e
+		})
+
+		switch {
+		case errors.Is(e, verification.ErrInvalidToken):
+			labeler.Add(attribute.Bool("error", true))
+			authorization.Respond(w, r, http.StatusBadRequest, "Invalid Verification Token")
+			return
+		case errors.Is(e, verification.ErrUnavailable):
+			labeler.Add(attribute.Bool("error", true))
+			authorization.Respond(w, r, http.StatusGone, "Verification Token Already Used or Expired")
+			return
+		case errors.Is(e, verification.ErrNotPending):
+			labeler.Add(attribute.Bool("error", true))
+			authorization.Respond(w, r, http.StatusConflict, "Account Isn't Pending Verification")
+			return
+		case e != nil:
+			slog.ErrorContext(ctx, "Unable to Redeem Verification Token", slog.String("error", e.Error()))
+
+			labeler.Add(attribute.Bool("error", true))
+			authorization.Respond(w, r, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+			return
+		}
+
+		slog.InfoContext(ctx, "Verified User", slog.Int64("user", user))
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Cache-Control", "no-store")
+		w.WriteHeader(http.StatusOK)
+
+		json.NewEncoder(w).Encode(&Response{ID: user, VerificationStatus: users.UserVerificationStatusVERIFIED})
+
+		return
+	}
+}
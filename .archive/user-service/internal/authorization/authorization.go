@@ -0,0 +1,165 @@
+package authorization
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/golang-jwt/jwt/v5"
+
+	"user-service/internal/token"
+)
+
+type key struct{}
+
+// Options is the configuration structure optionally mutated via the [Variadic] constructor used throughout the package.
+type Options struct {
+	Verification []token.Variadic // Verification represents the [token.Verify] setting(s), such as issuer, audience and denylist.
+	Excluded     []string         // Excluded rejects token(s) whose "aud" claim contains any of the value(s), e.g. single-purpose token(s) minted by the service itself.
+}
+
+// Variadic represents a functional constructor for the [Options] type. Typical callers of Variadic won't need to perform
+// nil checks as all implementations first construct an [Options] reference using packaged default(s).
+type Variadic func(o *Options)
+
+// options represents a default constructor.
+func options() *Options {
+	return &Options{}
+}
+
+// Verification appends to [Options.Verification].
+func Verification(settings ...token.Variadic) Variadic {
+	return func(o *Options) {
+		o.Verification = append(o.Verification, settings...)
+	}
+}
+
+// Exclude appends to [Options.Excluded].
+func Exclude(audiences ...string) Variadic {
+	return func(o *Options) {
+		o.Excluded = append(o.Excluded, audiences...)
+	}
+}
+
+// Claims returns the verified claims established by [Authenticate], or nil if the request was never authenticated.
+func Claims(ctx context.Context) *token.Claims {
+	if claims, ok := ctx.Value(key{}).(*token.Claims); ok {
+		return claims
+	}
+
+	return nil
+}
+
+// Authenticate verifies the request's bearer token - sourced from the "token" cookie, the "Authorization" header, or the
+// "X-Testing-Authorization" header - and stores its [token.Claims] in the request context.
+func Authenticate(settings ...Variadic) func(next http.Handler) http.Handler {
+	o := options()
+	for _, option := range settings {
+		option(o)
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := r.Context()
+
+			raw, e := bearer(r)
+			if e != nil {
+				slog.WarnContext(ctx, "Unable to Extract Bearer Token", slog.String("error", e.Error()))
+				Unauthorized(w, r, e.Error())
+				return
+			}
+
+			verified, e := token.Verify(ctx, raw, o.Verification...)
+			if e != nil {
+				var detail string
+				switch {
+				case errors.Is(e, jwt.ErrTokenMalformed):
+					detail = "Malformed JWT Token"
+				case errors.Is(e, jwt.ErrTokenSignatureInvalid):
+					detail = "Invalid JWT Token Signature"
+				case errors.Is(e, jwt.ErrTokenExpired):
+					detail = "Expired JWT Token"
+				case errors.Is(e, jwt.ErrTokenNotValidYet):
+					detail = "Invalid Future JWT Token"
+				case errors.Is(e, token.ErrRevoked):
+					detail = "Revoked JWT Token"
+				default:
+					var claim *token.ClaimError
+					if errors.As(e, &claim) {
+						detail = claim.Err.Error()
+					} else {
+						detail = http.StatusText(http.StatusUnauthorized)
+					}
+				}
+
+				Unauthorized(w, r, detail)
+				return
+			}
+
+			claims := verified.Claims.(*token.Claims)
+			for _, audience := range o.Excluded {
+				if slices.Contains(claims.Audience, audience) {
+					slog.WarnContext(ctx, "Rejected Token With Excluded Audience", slog.String("audience", audience))
+					Unauthorized(w, r, "Invalid JWT Token Audience")
+					return
+				}
+			}
+
+			ctx = context.WithValue(ctx, key{}, claims)
+
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// Require enforces all requirement(s) against the claims established by [Authenticate]. Unauthenticated requests receive a
+// 401 problem, while authenticated requests failing a requirement receive a 403 problem.
+func Require(requirements ...Requirement) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := r.Context()
+
+			claims := Claims(ctx)
+			if claims == nil {
+				Unauthorized(w, r, "Authentication Required")
+				return
+			}
+
+			for _, requirement := range requirements {
+				if e := requirement(r, claims); e != nil {
+					slog.WarnContext(ctx, "Authorization Requirement Failed", slog.String("subject", claims.Subject), slog.String("error", e.Error()))
+					Forbidden(w, r, e.Error())
+					return
+				}
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// bearer extracts the raw jwt from the request.
+func bearer(r *http.Request) (string, error) {
+	if cookie, e := r.Cookie("token"); e == nil && cookie.Value != "" {
+		return cookie.Value, nil
+	}
+
+	authorization := r.Header.Get("Authorization")
+	if authorization == "" {
+		authorization = r.Header.Get("X-Testing-Authorization") // To bypass proxy url header issues
+	}
+
+	if authorization == "" {
+		return "", errors.New("no valid authorization header or cookie found")
+	}
+
+	partials := strings.Split(authorization, " ")
+	if len(partials) != 2 || partials[0] != "Bearer" || partials[1] == "" {
+		return "", errors.New("invalid authorization header format")
+	}
+
+	return partials[1], nil
+}
@@ -0,0 +1,229 @@
+package authorization
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"user-service/internal/token"
+)
+
+func sign(t *testing.T, claims *token.Claims, settings ...token.Variadic) string {
+	t.Helper()
+
+	signed, e := token.Create(context.Background(), claims, settings...)
+	if e != nil {
+		t.Fatalf("unexpected error: %v", e)
+	}
+
+	return signed
+}
+
+func configure(t *testing.T) {
+	t.Helper()
+
+	private, e := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if e != nil {
+		t.Fatalf("unexpected error: %v", e)
+	}
+
+	token.Configure(private, nil)
+}
+
+// subject is a terminal handler echoing the authenticated subject.
+var subject = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+	w.Write([]byte(Claims(r.Context()).Subject))
+})
+
+// problem decodes and validates the recorded problem response.
+func problem(t *testing.T, w *httptest.ResponseRecorder, status int, challenge string) *Problem {
+	t.Helper()
+
+	if w.Code != status {
+		t.Fatalf("expected status %d, got %d", status, w.Code)
+	}
+
+	if content := w.Header().Get("Content-Type"); content != "application/problem+json" {
+		t.Errorf("expected content type %q, got %q", "application/problem+json", content)
+	}
+
+	header := w.Header().Get("WWW-Authenticate")
+	switch {
+	case challenge == "" && header != "":
+		t.Errorf("expected no challenge, got %q", header)
+	case challenge != "" && !(strings.HasPrefix(header, `Bearer error="`+challenge+`"`)):
+		t.Errorf("expected a %q challenge, got %q", challenge, header)
+	}
+
+	var value Problem
+	if e := json.NewDecoder(w.Body).Decode(&value); e != nil {
+		t.Fatalf("unexpected error: %v", e)
+	}
+
+	if value.Status != status || value.Title != http.StatusText(status) {
+		t.Errorf("unexpected problem: %+v", value)
+	}
+
+	return &value
+}
+
+func TestAuthenticate(t *testing.T) {
+	configure(t)
+
+	handler := Authenticate(Exclude("email-verification"))(subject)
+
+	valid := sign(t, &token.Claims{Subject: "user@example.com"})
+
+	tests := []struct {
+		name    string
+		prepare func(r *http.Request)
+		status  int
+		detail  string
+	}{
+		{"Header", func(r *http.Request) { r.Header.Set("Authorization", "Bearer "+valid) }, http.StatusOK, ""},
+		{"Cookie", func(r *http.Request) { r.AddCookie(&http.Cookie{Name: "token", Value: valid}) }, http.StatusOK, ""},
+		{"Testing-Header", func(r *http.Request) { r.Header.Set("X-Testing-Authorization", "Bearer "+valid) }, http.StatusOK, ""},
+		{"Missing", func(r *http.Request) {}, http.StatusUnauthorized, "no valid authorization header or cookie found"},
+		{"Scheme", func(r *http.Request) { r.Header.Set("Authorization", "Basic "+valid) }, http.StatusUnauthorized, "invalid authorization header format"},
+		{"Empty", func(r *http.Request) { r.Header.Set("Authorization", "Bearer ") }, http.StatusUnauthorized, "invalid authorization header format"},
+		{"Malformed", func(r *http.Request) { r.Header.Set("Authorization", "Bearer invalid") }, http.StatusUnauthorized, "Malformed JWT Token"},
+		{"Expired", func(r *http.Request) {
+			r.Header.Set("Authorization", "Bearer "+sign(t, &token.Claims{Subject: "user@example.com"}, token.TTL(-time.Hour)))
+		}, http.StatusUnauthorized, "Expired JWT Token"},
+		{"Excluded-Audience", func(r *http.Request) {
+			r.Header.Set("Authorization", "Bearer "+sign(t, &token.Claims{Subject: "user@example.com", Audience: []string{"email-verification"}}))
+		}, http.StatusUnauthorized, "Invalid JWT Token Audience"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "/users/me", nil)
+			test.prepare(r)
+
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, r)
+
+			if test.status == http.StatusOK {
+				if w.Code != http.StatusOK || w.Body.String() != "user@example.com" {
+					t.Errorf("expected the authenticated subject, got %d (%q)", w.Code, w.Body.String())
+				}
+
+				return
+			}
+
+			value := problem(t, w, test.status, "invalid_token")
+			if value.Detail != test.detail || value.Instance != "/users/me" {
+				t.Errorf("expected detail %q, got %+v", test.detail, value)
+			}
+		})
+	}
+}
+
+func TestAuthenticateVerification(t *testing.T) {
+	configure(t)
+
+	handler := Authenticate(Verification(token.Issuer("user-service")))(subject)
+
+	r := httptest.NewRequest(http.MethodGet, "/users/me", nil)
+	r.Header.Set("Authorization", "Bearer "+sign(t, &token.Claims{Subject: "user@example.com", Issuer: "other-service"}))
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, r)
+
+	if value := problem(t, w, http.StatusUnauthorized, "invalid_token"); value.Detail != token.ErrInvalidIssuer.Error() {
+		t.Errorf("expected detail %q, got %q", token.ErrInvalidIssuer.Error(), value.Detail)
+	}
+}
+
+func TestRequire(t *testing.T) {
+	configure(t)
+
+	owner := Subject(func(r *http.Request) string { return r.URL.Query().Get("email") })
+
+	tests := []struct {
+		name         string
+		claims       *token.Claims
+		target       string
+		requirements []Requirement
+		status       int
+	}{
+		{"Authenticated", &token.Claims{Subject: "user@example.com"}, "/", nil, http.StatusOK},
+		{"Scopes", &token.Claims{Subject: "user@example.com", Scopes: []string{"read", "write"}}, "/", []Requirement{Scopes("read", "write")}, http.StatusOK},
+		{"Missing-Scope", &token.Claims{Subject: "user@example.com", Scopes: []string{"read"}}, "/", []Requirement{Scopes("read", "write")}, http.StatusForbidden},
+		{"Roles", &token.Claims{Subject: "user@example.com", Roles: []string{"ROOT"}}, "/", []Requirement{Roles("MEMBER", "ROOT")}, http.StatusOK},
+		{"Missing-Role", &token.Claims{Subject: "user@example.com", Roles: []string{"MEMBER"}}, "/", []Requirement{Roles("ROOT")}, http.StatusForbidden},
+		{"Subject", &token.Claims{Subject: "user@example.com"}, "/?email=User@Example.com", []Requirement{owner}, http.StatusOK},
+		{"Subject-Implicit", &token.Claims{Subject: "user@example.com"}, "/", []Requirement{owner}, http.StatusOK},
+		{"Not-Owner", &token.Claims{Subject: "user@example.com"}, "/?email=other@example.com", []Requirement{owner}, http.StatusForbidden},
+		{"Any-Owner", &token.Claims{Subject: "user@example.com"}, "/?email=user@example.com", []Requirement{Any(owner, Roles("ROOT"))}, http.StatusOK},
+		{"Any-Role", &token.Claims{Subject: "root@example.com", Roles: []string{"ROOT"}}, "/?email=user@example.com", []Requirement{Any(owner, Roles("ROOT"))}, http.StatusOK},
+		{"Any-Neither", &token.Claims{Subject: "user@example.com"}, "/?email=other@example.com", []Requirement{Any(owner, Roles("ROOT"))}, http.StatusForbidden},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			handler := Authenticate()(Require(test.requirements...)(subject))
+
+			r := httptest.NewRequest(http.MethodGet, test.target, nil)
+			r.Header.Set("Authorization", "Bearer "+sign(t, test.claims))
+
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, r)
+
+			if test.status == http.StatusOK {
+				if w.Code != http.StatusOK {
+					t.Errorf("expected status %d, got %d (%s)", http.StatusOK, w.Code, w.Body.String())
+				}
+
+				return
+			}
+
+			problem(t, w, test.status, "insufficient_scope")
+		})
+	}
+}
+
+func TestRequireUnauthenticated(t *testing.T) {
+	w := httptest.NewRecorder()
+	Require()(subject).ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/users/me", nil))
+
+	if value := problem(t, w, http.StatusUnauthorized, "invalid_token"); value.Detail != "Authentication Required" {
+		t.Errorf("expected detail %q, got %q", "Authentication Required", value.Detail)
+	}
+}
+
+func TestRequirementErrors(t *testing.T) {
+	claims := &token.Claims{Subject: "user@example.com"}
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+
+	if e := Scopes("write")(r, claims); !(errors.Is(e, ErrInsufficientScope)) {
+		t.Errorf("expected %v, got %v", ErrInsufficientScope, e)
+	}
+
+	if e := Roles("ROOT")(r, claims); !(errors.Is(e, ErrInsufficientRole)) {
+		t.Errorf("expected %v, got %v", ErrInsufficientRole, e)
+	}
+
+	// --> a failing Any joins every requirement's error
+	e := Any(Subject(func(*http.Request) string { return "other@example.com" }), Roles("ROOT"))(r, claims)
+	if !(errors.Is(e, ErrNotOwner)) || !(errors.Is(e, ErrInsufficientRole)) {
+		t.Errorf("expected %v and %v, got %v", ErrNotOwner, ErrInsufficientRole, e)
+	}
+}
+
+func TestRespond(t *testing.T) {
+	w := httptest.NewRecorder()
+	Respond(w, httptest.NewRequest(http.MethodGet, "/users", nil), http.StatusBadRequest, "Invalid Cursor")
+
+	if value := problem(t, w, http.StatusBadRequest, ""); value.Detail != "Invalid Cursor" || value.Instance != "/users" {
+		t.Errorf("unexpected problem: %+v", value)
+	}
+}
@@ -0,0 +1,3 @@
+// Package authorization authenticates bearer tokens and enforces per-route requirement(s) - scopes, roles and resource
+// ownership - against the verified [token.Claims]. Failures are written as RFC 7807 "application/problem+json" responses.
+package authorization
@@ -0,0 +1,50 @@
+package authorization
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// Problem represents an RFC 7807 problem-details response.
+type Problem struct {
+	Type     string `json:"type"`
+	Title    string `json:"title"`
+	Status   int    `json:"status"`
+	Detail   string `json:"detail,omitempty"`
+	Instance string `json:"instance,omitempty"`
+}
+
+// write serializes the problem. 401 and 403 responses additionally include an RFC 6750 "WWW-Authenticate" challenge.
+func (p *Problem) write(w http.ResponseWriter, code string) {
+	if code != "" {
+		w.Header().Set("WWW-Authenticate", fmt.Sprintf("Bearer error=%q, error_description=%q", code, p.Detail))
+	}
+
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(p.Status)
+
+	json.NewEncoder(w).Encode(p)
+}
+
+// Unauthorized writes a 401 problem response.
+func Unauthorized(w http.ResponseWriter, r *http.Request, detail string) {
+	problem := &Problem{Type: "about:blank", Title: http.StatusText(http.StatusUnauthorized), Status: http.StatusUnauthorized, Detail: detail, Instance: r.URL.Path}
+
+	problem.write(w, "invalid_token")
+}
+
+// Forbidden writes a 403 problem response.
+func Forbidden(w http.ResponseWriter, r *http.Request, detail string) {
+	problem := &Problem{Type: "about:blank", Title: http.StatusText(http.StatusForbidden), Status: http.StatusForbidden, Detail: detail, Instance: r.URL.Path}
+
+	problem.write(w, "insufficient_scope")
+}
+
+// Respond writes a problem response of the status code, without an authentication challenge.
+func Respond(w http.ResponseWriter, r *http.Request, status int, detail string) {
+	problem := &Problem{Type: "about:blank", Title: http.StatusText(status), Status: status, Detail: detail, Instance: r.URL.Path}
+
+	problem.write(w, "")
+}
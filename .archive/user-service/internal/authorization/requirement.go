@@ -0,0 +1,77 @@
+package authorization
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"user-service/internal/token"
+)
+
+var (
+	ErrInsufficientScope = errors.New("insufficient scope")
+	ErrInsufficientRole  = errors.New("insufficient role")
+	ErrNotOwner          = errors.New("resource does not belong to the authenticated subject")
+)
+
+// Requirement evaluates an authenticated request's claims, returning a non-nil error if the request must be forbidden.
+type Requirement func(r *http.Request, claims *token.Claims) error
+
+// Scopes requires the token to have been granted every listed scope.
+func Scopes(scopes ...string) Requirement {
+	return func(r *http.Request, claims *token.Claims) error {
+		for _, scope := range scopes {
+			if !(claims.HasScope(scope)) {
+				return fmt.Errorf("%w: %q is required", ErrInsufficientScope, scope)
+			}
+		}
+
+		return nil
+	}
+}
+
+// Roles requires the token's subject to have been assigned at least one of the listed role(s).
+func Roles[Role ~string](roles ...Role) Requirement {
+	return func(r *http.Request, claims *token.Claims) error {
+		var names = make([]string, len(roles))
+		for index, role := range roles {
+			if claims.HasRole(string(role)) {
+				return nil
+			}
+
+			names[index] = string(role)
+		}
+
+		return fmt.Errorf("%w: one of (%s) is required", ErrInsufficientRole, strings.Join(names, ", "))
+	}
+}
+
+// Subject requires the resource identified by resolve to belong to the token's subject. An empty resolved value is
+// treated as the subject's own resource.
+func Subject(resolve func(r *http.Request) string) Requirement {
+	return func(r *http.Request, claims *token.Claims) error {
+		if resource := resolve(r); resource != "" && !(strings.EqualFold(resource, claims.Subject)) {
+			return ErrNotOwner
+		}
+
+		return nil
+	}
+}
+
+// Any passes if at least one of the requirement(s) passes - e.g. Any(Subject(...), Roles(users.UserAccountTypeROOT)).
+func Any(requirements ...Requirement) Requirement {
+	return func(r *http.Request, claims *token.Claims) error {
+		var exceptions []error
+		for _, requirement := range requirements {
+			e := requirement(r, claims)
+			if e == nil {
+				return nil
+			}
+
+			exceptions = append(exceptions, e)
+		}
+
+		return errors.Join(exceptions...)
+	}
+}
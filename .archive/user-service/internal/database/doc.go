@@ -0,0 +1,4 @@
+// Package database owns the service's process-wide PostgreSQL connection [pgxpool.Pool]. The pool is created once at
+// startup, health-checks its idle connection(s), traces every query via OpenTelemetry, and exposes its statistics as
+// OpenTelemetry metrics.
+package database
@@ -0,0 +1,25 @@
+package database
+
+import (
+	"errors"
+
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// code returns the error's PostgreSQL SQLSTATE code and the name of the constraint it concerns, if any.
+func code(e error) (state string, constraint string) {
+	var exception *pgconn.PgError
+	if errors.As(e, &exception) {
+		return exception.Code, exception.ConstraintName
+	}
+
+	return "", ""
+}
+
+// Unique reports whether the error is a unique-violation (SQLSTATE 23505) of the named constraint or unique index; an
+// empty name matches any unique-violation.
+func Unique(e error, name string) bool {
+	state, constraint := code(e)
+
+	return state == "23505" && (name == "" || constraint == name)
+}
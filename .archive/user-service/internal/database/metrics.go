@@ -0,0 +1,81 @@
+package database
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// instrument registers the pool's [pgxpool.Stat] as asynchronous OpenTelemetry instrument(s), observed on each collection.
+// The global meter provider is used, which forwards to the provider configured during telemetry setup - even if
+// registered beforehand.
+func instrument(pool *pgxpool.Pool) error {
+	meter := otel.Meter(scope)
+
+	usage, e := meter.Int64ObservableUpDownCounter("db.client.connections.usage", metric.WithUnit("{connection}"), metric.WithDescription("The number of connections that are currently in the state described by the state attribute."))
+	if e != nil {
+		return e
+	}
+
+	maximum, e := meter.Int64ObservableUpDownCounter("db.client.connections.max", metric.WithUnit("{connection}"), metric.WithDescription("The maximum number of open connections allowed."))
+	if e != nil {
+		return e
+	}
+
+	acquisitions, e := meter.Int64ObservableCounter("db.client.connections.acquires", metric.WithUnit("{acquire}"), metric.WithDescription("The cumulative number of successful connection acquisitions, by whether the pool had to wait."))
+	if e != nil {
+		return e
+	}
+
+	canceled, e := meter.Int64ObservableCounter("db.client.connections.acquires.canceled", metric.WithUnit("{acquire}"), metric.WithDescription("The cumulative number of connection acquisitions canceled by their context."))
+	if e != nil {
+		return e
+	}
+
+	waiting, e := meter.Float64ObservableCounter("db.client.connections.acquire_time", metric.WithUnit("s"), metric.WithDescription("The cumulative time spent acquiring connections."))
+	if e != nil {
+		return e
+	}
+
+	created, e := meter.Int64ObservableCounter("db.client.connections.created", metric.WithUnit("{connection}"), metric.WithDescription("The cumulative number of connections opened."))
+	if e != nil {
+		return e
+	}
+
+	destroyed, e := meter.Int64ObservableCounter("db.client.connections.destroyed", metric.WithUnit("{connection}"), metric.WithDescription("The cumulative number of connections closed, by reason."))
+	if e != nil {
+		return e
+	}
+
+	var (
+		idle         = metric.WithAttributes(attribute.String("state", "idle"))
+		used         = metric.WithAttributes(attribute.String("state", "used"))
+		constructing = metric.WithAttributes(attribute.String("state", "constructing"))
+	)
+
+	_, e = meter.RegisterCallback(func(ctx context.Context, observer metric.Observer) error {
+		statistics := pool.Stat()
+
+		observer.ObserveInt64(usage, int64(statistics.IdleConns()), idle)
+		observer.ObserveInt64(usage, int64(statistics.AcquiredConns()), used)
+		observer.ObserveInt64(usage, int64(statistics.ConstructingConns()), constructing)
+		observer.ObserveInt64(maximum, int64(statistics.MaxConns()))
+
+		// --> an "empty" acquire had to wait for a connection to be created or released
+		observer.ObserveInt64(acquisitions, statistics.AcquireCount()-statistics.EmptyAcquireCount(), metric.WithAttributes(attribute.Bool("waited", false)))
+		observer.ObserveInt64(acquisitions, statistics.EmptyAcquireCount(), metric.WithAttributes(attribute.Bool("waited", true)))
+		observer.ObserveInt64(canceled, statistics.CanceledAcquireCount())
+		observer.ObserveFloat64(waiting, statistics.AcquireDuration().Seconds())
+
+		observer.ObserveInt64(created, statistics.NewConnsCount())
+		observer.ObserveInt64(destroyed, statistics.MaxLifetimeDestroyCount(), metric.WithAttributes(attribute.String("reason", "lifetime")))
+		observer.ObserveInt64(destroyed, statistics.MaxIdleDestroyCount(), metric.WithAttributes(attribute.String("reason", "idle")))
+
+		return nil
+	}, usage, maximum, acquisitions, canceled, waiting, created, destroyed)
+
+	return e
+}
@@ -0,0 +1,84 @@
+package database
+
+import (
+	"time"
+
+	"github.com/x-ethr/pg"
+)
+
+// Options is the configuration structure optionally mutated via the [Variadic] constructor used throughout the package.
+type Options struct {
+	DSN string // DSN represents the PostgreSQL connection string. Defaults to the environment-derived [pg.DSN].
+
+	Minimum int32 // Minimum represents the number of connection(s) the pool keeps open, even while idle. Defaults to 1.
+	Maximum int32 // Maximum represents the upper bound of open connection(s). Defaults to 10.
+
+	Lifetime    time.Duration // Lifetime represents the duration after which a connection is closed and replaced. Defaults to one hour.
+	Idle        time.Duration // Idle represents the duration after which an idle connection above [Options.Minimum] is closed. Defaults to 30 minutes.
+	HealthCheck time.Duration // HealthCheck represents the interval between health check(s) of idle connection(s). Defaults to one minute.
+
+	Timeout time.Duration // Timeout bounds establishing, and verifying, the pool's initial connection(s). Defaults to 10 seconds.
+}
+
+// Variadic represents a functional constructor for the [Options] type. Typical callers of Variadic won't need to perform
+// nil checks as all implementations first construct an [Options] reference using packaged default(s).
+type Variadic func(o *Options)
+
+// options represents a default constructor.
+func options() *Options {
+	return &Options{
+		DSN: pg.DSN(),
+
+		Minimum: 1,
+		Maximum: 10,
+
+		Lifetime:    time.Hour,
+		Idle:        30 * time.Minute,
+		HealthCheck: time.Minute,
+
+		Timeout: 10 * time.Second,
+	}
+}
+
+// DSN sets [Options.DSN].
+func DSN(dsn string) Variadic {
+	return func(o *Options) {
+		o.DSN = dsn
+	}
+}
+
+// Connections sets [Options.Minimum] and [Options.Maximum].
+func Connections(minimum, maximum int32) Variadic {
+	return func(o *Options) {
+		o.Minimum = minimum
+		o.Maximum = maximum
+	}
+}
+
+// Lifetime sets [Options.Lifetime].
+func Lifetime(duration time.Duration) Variadic {
+	return func(o *Options) {
+		o.Lifetime = duration
+	}
+}
+
+// Idle sets [Options.Idle].
+func Idle(duration time.Duration) Variadic {
+	return func(o *Options) {
+		o.Idle = duration
+	}
+}
+
+// HealthCheck sets [Options.HealthCheck].
+func HealthCheck(duration time.Duration) Variadic {
+	return func(o *Options) {
+		o.HealthCheck = duration
+	}
+}
+
+// Timeout sets [Options.Timeout].
+func Timeout(duration time.Duration) Variadic {
+	return func(o *Options) {
+		o.Timeout = duration
+	}
+}
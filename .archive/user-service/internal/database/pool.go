@@ -0,0 +1,66 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// scope is the OpenTelemetry instrumentation scope of the package's span(s) and metric(s).
+const scope = "user-service/internal/database"
+
+// Pool establishes the process-wide connection pool, verifying connectivity before returning. Callers own the pool and
+// should [pgxpool.Pool.Close] it during shutdown.
+//
+// Idle connection(s) are health-checked every [Options.HealthCheck]; additionally, the pool pings any connection that sat
+// idle for more than a second before handing it out, transparently replacing broken connection(s).
+func Pool(ctx context.Context, settings ...Variadic) (*pgxpool.Pool, error) {
+	o := options()
+	for _, option := range settings {
+		option(o)
+	}
+
+	if o.Minimum < 0 || o.Maximum < 1 || o.Minimum > o.Maximum {
+		return nil, fmt.Errorf("invalid pool size: minimum (%d) must be between 0 and maximum (%d), and maximum at least 1", o.Minimum, o.Maximum)
+	}
+
+	configuration, e := pgxpool.ParseConfig(o.DSN)
+	if e != nil {
+		return nil, fmt.Errorf("unable to parse database dsn: %w", e)
+	}
+
+	configuration.MinConns = o.Minimum
+	configuration.MaxConns = o.Maximum
+	configuration.MaxConnLifetime = o.Lifetime
+	configuration.MaxConnLifetimeJitter = o.Lifetime / 10 // --> avoid recycling every connection at once
+	configuration.MaxConnIdleTime = o.Idle
+	configuration.HealthCheckPeriod = o.HealthCheck
+
+	configuration.ConnConfig.Tracer = &tracer{}
+
+	ctx, cancel := context.WithTimeout(ctx, o.Timeout)
+	defer cancel()
+
+	pool, e := pgxpool.NewWithConfig(ctx, configuration)
+	if e != nil {
+		return nil, fmt.Errorf("unable to create database pool: %w", e)
+	}
+
+	if e := pool.Ping(ctx); e != nil {
+		pool.Close()
+
+		return nil, fmt.Errorf("unable to verify database connectivity: %w", e)
+	}
+
+	if e := instrument(pool); e != nil {
+		pool.Close()
+
+		return nil, fmt.Errorf("unable to register database pool metrics: %w", e)
+	}
+
+	slog.InfoContext(ctx, "Established Database Connection Pool", slog.Int("minimum", int(o.Minimum)), slog.Int("maximum", int(o.Maximum)))
+
+	return pool, nil
+}
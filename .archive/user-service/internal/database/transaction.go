@@ -0,0 +1,131 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math/rand/v2"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	"user-service/models/users"
+)
+
+// TxOptions configures a [WithTx] transaction. A nil *TxOptions is equivalent to the zero value.
+type TxOptions struct {
+	Isolation pgx.TxIsoLevel // Isolation represents the transaction's isolation level. Defaults to the server's default, typically read committed.
+	ReadOnly  bool           // ReadOnly starts the transaction in read-only access mode.
+
+	Attempts int           // Attempts represents the maximum number of execution(s), including retries. Defaults to 3.
+	Backoff  time.Duration // Backoff represents the base delay between retries, doubled per attempt and jittered. Defaults to 25 milliseconds.
+}
+
+// retryable reports whether the error is a transient transaction conflict - a serialization failure (SQLSTATE 40001) or
+// a deadlock (SQLSTATE 40P01) - after which the transaction can safely be retried from the start.
+func retryable(e error) bool {
+	state, _ := code(e)
+
+	return state == "40001" || state == "40P01"
+}
+
+// WithTx executes fn within a transaction acquired from the pool, committing if fn returns nil. The transaction is rolled
+// back if fn returns an error or panics - the panic is re-raised after rollback.
+//
+// Serialization failures and deadlocks, whether raised by fn or the commit, cause the entire transaction to be retried up
+// to [TxOptions.Attempts] times; fn must therefore be safe to re-execute and shouldn't have side effect(s) outside tx.
+// fn's error is returned as-is, allowing callers to match their own sentinel error(s) via [errors.Is].
+func WithTx(ctx context.Context, pool *pgxpool.Pool, opts *TxOptions, fn func(tx users.DBTX) error) (e error) {
+	var settings TxOptions
+	if opts != nil {
+		settings = *opts
+	}
+
+	if settings.Attempts <= 0 {
+		settings.Attempts = 3
+	}
+
+	if settings.Backoff <= 0 {
+		settings.Backoff = 25 * time.Millisecond
+	}
+
+	mode := pgx.ReadWrite
+	if settings.ReadOnly {
+		mode = pgx.ReadOnly
+	}
+
+	ctx, span := otel.Tracer(scope).Start(ctx, "database.transaction", trace.WithAttributes(attribute.String("db.system", "postgresql"), attribute.String("db.transaction.isolation", string(settings.Isolation)), attribute.Bool("db.transaction.read_only", settings.ReadOnly)))
+
+	defer func() {
+		if e != nil {
+			span.RecordError(e)
+			span.SetStatus(codes.Error, e.Error())
+		}
+
+		span.End()
+	}()
+
+	for attempt := 1; ; attempt++ {
+		span.SetAttributes(attribute.Int("db.transaction.attempts", attempt))
+
+		e = execute(ctx, pool, pgx.TxOptions{IsoLevel: settings.Isolation, AccessMode: mode}, fn)
+		if e == nil || !(retryable(e)) || attempt >= settings.Attempts {
+			return e
+		}
+
+		// --> exponential backoff with full jitter, so conflicting transaction(s) don't retry in lockstep
+		delay := time.Duration(rand.Int64N(int64(settings.Backoff) << (attempt - 1)))
+
+		slog.WarnContext(ctx, "Retrying Conflicted Database Transaction", slog.Int("attempt", attempt), slog.Duration("delay", delay), slog.String("error", e.Error()))
+
+		span.AddEvent("retry", trace.WithAttributes(attribute.Int("attempt", attempt), attribute.String("error", e.Error())))
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+
+			return errors.Join(e, ctx.Err())
+		case <-timer.C:
+		}
+	}
+}
+
+// execute runs a single transaction attempt.
+func execute(ctx context.Context, pool *pgxpool.Pool, options pgx.TxOptions, fn func(tx users.DBTX) error) (e error) {
+	tx, e := pool.BeginTx(ctx, options)
+	if e != nil {
+		return fmt.Errorf("unable to begin database transaction: %w", e)
+	}
+
+	defer func() {
+		if exception := recover(); exception != nil {
+			// --> a context-independent rollback, as the panic may stem from cancellation
+			_ = tx.Rollback(context.WithoutCancel(ctx))
+
+			panic(exception)
+		}
+
+		if e != nil {
+			if rollback := tx.Rollback(context.WithoutCancel(ctx)); rollback != nil && !(errors.Is(rollback, pgx.ErrTxClosed)) {
+				slog.WarnContext(ctx, "Unable to Rollback Database Transaction", slog.String("error", rollback.Error()))
+			}
+		}
+	}()
+
+	if e = fn(tx); e != nil {
+		return e
+	}
+
+	if e = tx.Commit(ctx); e != nil {
+		return fmt.Errorf("unable to commit database transaction: %w", e)
+	}
+
+	return nil
+}
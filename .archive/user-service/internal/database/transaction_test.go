@@ -0,0 +1,261 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"user-service/models/users"
+)
+
+// conflict raises a serialization failure (SQLSTATE 40001) from within the transaction.
+const conflict = `DO $$ BEGIN RAISE EXCEPTION 'forced serialization failure' USING ERRCODE = '40001'; END $$`
+
+func TestRetryable(t *testing.T) {
+	tests := []struct {
+		name     string
+		e        error
+		expected bool
+	}{
+		{"Serialization-Failure", &pgconn.PgError{Code: "40001"}, true},
+		{"Deadlock", &pgconn.PgError{Code: "40P01"}, true},
+		{"Wrapped", fmt.Errorf("unable to commit database transaction: %w", &pgconn.PgError{Code: "40001"}), true},
+		{"Unique-Violation", &pgconn.PgError{Code: "23505"}, false},
+		{"Generic", errors.New("generic"), false},
+		{"Nil", nil, false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if result := retryable(test.e); result != test.expected {
+				t.Errorf("expected %t, got %t", test.expected, result)
+			}
+		})
+	}
+}
+
+// table connects to a disposable PostgreSQL database, specified by the "DATABASE_TEST_DSN" environment variable, and creates
+// a uniquely named table that's dropped once the test completes.
+func table(t *testing.T) (*pgxpool.Pool, string) {
+	t.Helper()
+
+	dsn := os.Getenv("DATABASE_TEST_DSN")
+	if dsn == "" {
+		t.Skip("DATABASE_TEST_DSN not set")
+	}
+
+	ctx := context.Background()
+
+	pool, e := Pool(ctx, DSN(dsn), Connections(1, 4))
+	if e != nil {
+		t.Fatalf("unexpected error: %v", e)
+	}
+
+	name := pgx.Identifier{fmt.Sprintf("transaction-test-%d", time.Now().UnixNano())}.Sanitize()
+
+	if _, e := pool.Exec(ctx, fmt.Sprintf(`CREATE TABLE %s (value integer NOT NULL)`, name)); e != nil {
+		pool.Close()
+
+		t.Fatalf("unexpected error: %v", e)
+	}
+
+	t.Cleanup(func() {
+		if _, e := pool.Exec(context.Background(), fmt.Sprintf(`DROP TABLE %s`, name)); e != nil {
+			t.Errorf("unable to drop test table: %v", e)
+		}
+
+		pool.Close()
+	})
+
+	return pool, name
+}
+
+func values(t *testing.T, pool *pgxpool.Pool, name string) []int {
+	t.Helper()
+
+	rows, e := pool.Query(context.Background(), fmt.Sprintf(`SELECT value FROM %s ORDER BY value`, name))
+	if e != nil {
+		t.Fatalf("unexpected error: %v", e)
+	}
+
+	result, e := pgx.CollectRows(rows, pgx.RowTo[int])
+	if e != nil {
+		t.Fatalf("unexpected error: %v", e)
+	}
+
+	return result
+}
+
+// TestWithTxRetry requires a disposable PostgreSQL database, specified by the "DATABASE_TEST_DSN" environment variable.
+func TestWithTxRetry(t *testing.T) {
+	pool, name := table(t)
+
+	ctx := context.Background()
+
+	// --> two serializable transaction(s) each count the rows, then insert the count: a write skew that postgres must
+	// abort one of (SQLSTATE 40001). Both first attempt(s) read before either writes.
+	var barrier sync.WaitGroup
+	barrier.Add(2)
+
+	var attempts atomic.Int32
+
+	var group sync.WaitGroup
+	failures := make(chan error, 2)
+	for range 2 {
+		group.Add(1)
+		go func() {
+			defer group.Done()
+
+			first := true
+			failures <- WithTx(ctx, pool, &TxOptions{Isolation: pgx.Serializable, Attempts: 3, Backoff: time.Millisecond}, func(tx users.DBTX) error {
+				attempts.Add(1)
+
+				var total int
+				if e := tx.QueryRow(ctx, fmt.Sprintf(`SELECT count(*) FROM %s`, name)).Scan(&total); e != nil {
+					return e
+				}
+
+				if first {
+					first = false
+
+					barrier.Done()
+					barrier.Wait()
+				}
+
+				_, e := tx.Exec(ctx, fmt.Sprintf(`INSERT INTO %s (value) VALUES ($1)`, name), total)
+
+				return e
+			})
+		}()
+	}
+
+	group.Wait()
+	close(failures)
+
+	for e := range failures {
+		if e != nil {
+			t.Fatalf("unexpected error: %v", e)
+		}
+	}
+
+	if total := attempts.Load(); total != 3 {
+		t.Errorf("expected 3 attempts (one retry), got %d", total)
+	}
+
+	// --> the retried transaction observed the committed row
+	if result := values(t, pool, name); len(result) != 2 || result[0] != 0 || result[1] != 1 {
+		t.Errorf("expected values [0 1], got %v", result)
+	}
+}
+
+// TestWithTxForcedRetry requires a disposable PostgreSQL database, specified by the "DATABASE_TEST_DSN" environment variable.
+func TestWithTxForcedRetry(t *testing.T) {
+	pool, name := table(t)
+
+	ctx := context.Background()
+
+	attempts := 0
+	e := WithTx(ctx, pool, &TxOptions{Backoff: time.Millisecond}, func(tx users.DBTX) error {
+		attempts++
+
+		if _, e := tx.Exec(ctx, fmt.Sprintf(`INSERT INTO %s (value) VALUES ($1)`, name), attempts); e != nil {
+			return e
+		}
+
+		if attempts == 1 {
+			_, e := tx.Exec(ctx, conflict)
+			return e
+		}
+
+		return nil
+	})
+
+	if e != nil {
+		t.Fatalf("unexpected error: %v", e)
+	}
+
+	if attempts != 2 {
+		t.Errorf("expected 2 attempts, got %d", attempts)
+	}
+
+	// --> the first attempt's insert was rolled back
+	if result := values(t, pool, name); len(result) != 1 || result[0] != 2 {
+		t.Errorf("expected values [2], got %v", result)
+	}
+
+	// --> retries are bounded by the configured attempts
+	attempts = 0
+	e = WithTx(ctx, pool, &TxOptions{Attempts: 2, Backoff: time.Millisecond}, func(tx users.DBTX) error {
+		attempts++
+
+		_, e := tx.Exec(ctx, conflict)
+
+		return e
+	})
+
+	if !(retryable(e)) || attempts != 2 {
+		t.Errorf("expected a serialization failure after 2 attempts, got %v after %d", e, attempts)
+	}
+}
+
+// TestWithTxRollback requires a disposable PostgreSQL database, specified by the "DATABASE_TEST_DSN" environment variable.
+func TestWithTxRollback(t *testing.T) {
+	pool, name := table(t)
+
+	ctx := context.Background()
+
+	sentinel := errors.New("sentinel")
+
+	attempts := 0
+	e := WithTx(ctx, pool, nil, func(tx users.DBTX) error {
+		attempts++
+
+		if _, e := tx.Exec(ctx, fmt.Sprintf(`INSERT INTO %s (value) VALUES (1)`, name)); e != nil {
+			return e
+		}
+
+		return sentinel
+	})
+
+	if !(errors.Is(e, sentinel)) {
+		t.Errorf("expected %v, got %v", sentinel, e)
+	}
+
+	if attempts != 1 {
+		t.Errorf("expected a non-retryable error to not be retried, got %d attempts", attempts)
+	}
+
+	if result := values(t, pool, name); len(result) != 0 {
+		t.Errorf("expected the insert to be rolled back, got %v", result)
+	}
+
+	// --> a panic also rolls back, and is re-raised
+	func() {
+		defer func() {
+			if exception := recover(); exception != "panic" {
+				t.Errorf("expected the panic to be re-raised, got %v", exception)
+			}
+		}()
+
+		_ = WithTx(ctx, pool, nil, func(tx users.DBTX) error {
+			if _, e := tx.Exec(ctx, fmt.Sprintf(`INSERT INTO %s (value) VALUES (2)`, name)); e != nil {
+				return e
+			}
+
+			panic("panic")
+		})
+	}()
+
+	if result := values(t, pool, name); len(result) != 0 {
+		t.Errorf("expected the insert to be rolled back after a panic, got %v", result)
+	}
+}
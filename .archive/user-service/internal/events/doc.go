@@ -0,0 +1,3 @@
+// Package events publishes the service's domain event(s) to the "user-service" Redis stream, consumed by downstream
+// service(s) such as the redis-streams poller.
+package events
@@ -0,0 +1,119 @@
+package events
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Stream is the name of the Redis stream the service publishes to.
+const Stream = "user-service"
+
+// Event represents a publishable domain event.
+type Event interface {
+	// Type returns the event's discriminator, published as the message's "type" field.
+	Type() string
+
+	// Values returns the event's message field(s), excluding "type".
+	Values() map[string]interface{}
+}
+
+// Publisher publishes [Event](s). Implementations must be safe for concurrent use.
+type Publisher interface {
+	Publish(ctx context.Context, event Event) error
+}
+
+// Redis is a [Publisher] appending each event to a Redis stream via XADD.
+type Redis struct {
+	Client *redis.Client
+	Stream string // Stream represents the target stream's name. Defaults to [Stream].
+}
+
+func (r *Redis) Publish(ctx context.Context, event Event) error {
+	stream := r.Stream
+	if stream == "" {
+		stream = Stream
+	}
+
+	values := event.Values()
+	values["type"] = event.Type()
+
+	id, e := r.Client.XAdd(ctx, &redis.XAddArgs{Stream: stream, Values: values}).Result()
+	if e != nil {
+		return e
+	}
+
+	slog.DebugContext(ctx, "Published Event", slog.String("stream", stream), slog.String("type", event.Type()), slog.String("id", id))
+
+	return nil
+}
+
+// Registration is published upon a new user's registration.
+type Registration struct {
+	ID           int64
+	Email        string
+	Avatar       *string
+	Creation     time.Time
+	Verification string // Verification represents the user's single-use email verification link.
+}
+
+func (r *Registration) Type() string {
+	return "registration"
+}
+
+func (r *Registration) Values() map[string]interface{} {
+	values := map[string]interface{}{
+		"id":           r.ID,
+		"email":        r.Email,
+		"creation":     r.Creation.UTC().Format(time.RFC3339Nano),
+		"verification": r.Verification,
+	}
+
+	// --> stream field(s) can't be null; an absent key decodes as nil
+	if r.Avatar != nil {
+		values["avatar"] = *(r.Avatar)
+	}
+
+	return values
+}
+
+// Deletion is published once a soft-deleted user has been purged, so dependent service(s) can erase their copies.
+type Deletion struct {
+	ID       int64
+	Email    string
+	Deletion time.Time // Deletion represents the user's original soft-deletion timestamp.
+}
+
+func (d *Deletion) Type() string {
+	return "user.deleted"
+}
+
+func (d *Deletion) Values() map[string]interface{} {
+	return map[string]interface{}{
+		"id":       d.ID,
+		"email":    d.Email,
+		"deletion": d.Deletion.UTC().Format(time.RFC3339Nano),
+	}
+}
+
+// Update is published upon a change to a user's profile.
+type Update struct {
+	ID           int64
+	Fields       []string  // Fields represents the changed attribute(s), by their json name(s).
+	Modification time.Time // Modification represents the update's timestamp.
+}
+
+func (u *Update) Type() string {
+	return "user.updated"
+}
+
+func (u *Update) Values() map[string]interface{} {
+	return map[string]interface{}{
+		"id":           u.ID,
+		"fields":       strings.Join(u.Fields, ","),
+		"modification": u.Modification.UTC().Format(time.RFC3339Nano),
+	}
+}
@@ -0,0 +1,3 @@
+// Package imaging normalizes uploaded avatar image(s): the content is sniffed against an allowlist, decoded and re-encoded
+// - discarding all metadata, such as EXIF location(s) - and resized into the standard rendition(s).
+package imaging
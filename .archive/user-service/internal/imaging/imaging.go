@@ -0,0 +1,194 @@
+package imaging
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/draw"
+	"image/jpeg"
+	"image/png"
+
+	_ "image/gif" // --> registers the gif decoder
+
+	"github.com/gabriel-vasile/mimetype"
+)
+
+const (
+	Dimension = 1024       // Dimension is the maximum width and height of the "full" rendition.
+	Pixels    = 16_000_000 // Pixels is the maximum accepted source image area, guarding against decompression bomb(s).
+	Quality   = 85         // Quality is the JPEG encoding quality.
+)
+
+// Thumbnails are the standard square thumbnail size(s), in pixel(s).
+var Thumbnails = []int{256, 128, 64}
+
+var (
+	ErrUnsupportedType = errors.New("unsupported image type")
+	ErrInvalidImage    = errors.New("invalid image")
+	ErrTooLarge        = errors.New("image dimensions are too large")
+)
+
+// Allowed is the allowlist of accepted source MIME type(s), as sniffed from the content rather than declared by the client.
+var Allowed = []string{"image/jpeg", "image/png", "image/gif"}
+
+// Rendition represents a normalized, metadata-free encoding of the source image.
+type Rendition struct {
+	Name        string // Name is "full" for the aspect-preserving rendition, or the thumbnail's size (e.g. "128").
+	Width       int
+	Height      int
+	ContentType string
+	Extension   string
+	Data        []byte
+}
+
+// Process sniffs, decodes and normalizes the source image into the "full" rendition - bounded by [Dimension] - followed by
+// a center-cropped square thumbnail per [Thumbnails] size. JPEG source(s) are re-encoded as JPEG; PNG and GIF source(s),
+// which may carry transparency, as PNG. Animated GIF(s) are reduced to their first frame.
+func Process(data []byte) ([]Rendition, error) {
+	detected := mimetype.Detect(data)
+	if !(mimetype.EqualsAny(detected.String(), Allowed...)) {
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedType, detected.String())
+	}
+
+	configuration, _, e := image.DecodeConfig(bytes.NewReader(data))
+	if e != nil {
+		return nil, errors.Join(ErrInvalidImage, e)
+	} else if configuration.Width <= 0 || configuration.Height <= 0 {
+		return nil, ErrInvalidImage
+	} else if configuration.Width*configuration.Height > Pixels {
+		return nil, fmt.Errorf("%w: %dx%d exceeds %d pixel(s)", ErrTooLarge, configuration.Width, configuration.Height, Pixels)
+	}
+
+	source, _, e := image.Decode(bytes.NewReader(data))
+	if e != nil {
+		return nil, errors.Join(ErrInvalidImage, e)
+	}
+
+	opaque := detected.Is("image/jpeg")
+
+	// --> bound the full rendition, preserving its aspect ratio
+	width, height := fit(source.Bounds().Dx(), source.Bounds().Dy(), Dimension)
+	full := scale(source, source.Bounds(), width, height)
+
+	renditions := make([]Rendition, 0, 1+len(Thumbnails))
+
+	rendition, e := encode("full", full, opaque)
+	if e != nil {
+		return nil, e
+	}
+
+	renditions = append(renditions, rendition)
+
+	// --> thumbnails derive from the bounded rendition, rather than a potentially much larger source
+	for _, size := range Thumbnails {
+		rendition, e := encode(fmt.Sprintf("%d", size), scale(full, square(full.Bounds()), size, size), opaque)
+		if e != nil {
+			return nil, e
+		}
+
+		renditions = append(renditions, rendition)
+	}
+
+	return renditions, nil
+}
+
+// encode serializes the image as a [Rendition]. Only pixel data is written; no metadata survives.
+func encode(name string, img *image.RGBA, opaque bool) (Rendition, error) {
+	var buffer bytes.Buffer
+
+	rendition := Rendition{Name: name, Width: img.Bounds().Dx(), Height: img.Bounds().Dy()}
+	if opaque {
+		rendition.ContentType, rendition.Extension = "image/jpeg", ".jpg"
+		if e := jpeg.Encode(&buffer, img, &jpeg.Options{Quality: Quality}); e != nil {
+			return Rendition{}, fmt.Errorf("unable to encode %s rendition: %w", name, e)
+		}
+	} else {
+		rendition.ContentType, rendition.Extension = "image/png", ".png"
+		if e := png.Encode(&buffer, img); e != nil {
+			return Rendition{}, fmt.Errorf("unable to encode %s rendition: %w", name, e)
+		}
+	}
+
+	rendition.Data = buffer.Bytes()
+
+	return rendition, nil
+}
+
+// fit returns the dimension(s) bounding width x height within limit x limit, preserving the aspect ratio. Image(s) are
+// never enlarged.
+func fit(width, height, limit int) (int, int) {
+	if width <= limit && height <= limit {
+		return width, height
+	}
+
+	if width >= height {
+		return limit, max(1, height*limit/width)
+	}
+
+	return max(1, width*limit/height), limit
+}
+
+// square returns the largest centered square within the bounds.
+func square(bounds image.Rectangle) image.Rectangle {
+	size := min(bounds.Dx(), bounds.Dy())
+	x := bounds.Min.X + (bounds.Dx()-size)/2
+	y := bounds.Min.Y + (bounds.Dy()-size)/2
+
+	return image.Rect(x, y, x+size, y+size)
+}
+
+// scale resamples the source region to width x height. Downscaling averages each destination pixel's source area (a box
+// filter); upscaling degrades to nearest-neighbor.
+func scale(source image.Image, region image.Rectangle, width, height int) *image.RGBA {
+	destination := image.NewRGBA(image.Rect(0, 0, width, height))
+
+	sw, sh := region.Dx(), region.Dy()
+
+	// --> averaging requires premultiplied alpha, otherwise transparent pixel(s) bleed their color. Rather than converting
+	// the entire region up front, only the source row(s) backing a single destination row are converted at a time.
+	src, converted := source.(*image.RGBA)
+
+	var buffer []uint8
+	if !(converted) {
+		buffer = make([]uint8, 4*sw*max(1, (sh+height-1)/height))
+	}
+
+	for dy := 0; dy < height; dy++ {
+		y0 := region.Min.Y + dy*sh/height
+		y1 := max(y0+1, region.Min.Y+(dy+1)*sh/height)
+
+		if !(converted) {
+			band := image.Rect(region.Min.X, y0, region.Max.X, y1)
+
+			src = &image.RGBA{Pix: buffer[:4*sw*band.Dy()], Stride: 4 * sw, Rect: band}
+			draw.Draw(src, band, source, band.Min, draw.Src)
+		}
+
+		for dx := 0; dx < width; dx++ {
+			x0 := region.Min.X + dx*sw/width
+			x1 := max(x0+1, region.Min.X+(dx+1)*sw/width)
+
+			var r, g, b, a, count uint64
+			for y := y0; y < y1; y++ {
+				offset := src.PixOffset(x0, y)
+				for x := x0; x < x1; x++ {
+					r += uint64(src.Pix[offset])
+					g += uint64(src.Pix[offset+1])
+					b += uint64(src.Pix[offset+2])
+					a += uint64(src.Pix[offset+3])
+					offset += 4
+					count++
+				}
+			}
+
+			offset := destination.PixOffset(dx, dy)
+			destination.Pix[offset] = uint8(r / count)
+			destination.Pix[offset+1] = uint8(g / count)
+			destination.Pix[offset+2] = uint8(b / count)
+			destination.Pix[offset+3] = uint8(a / count)
+		}
+	}
+
+	return destination
+}
@@ -0,0 +1,160 @@
+package imaging
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"hash/crc32"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/jpeg"
+	"image/png"
+	"testing"
+)
+
+// sample encodes a width x height test image; its left half red and right half blue.
+func sample(t *testing.T, width, height int, encoder func(buffer *bytes.Buffer, img image.Image) error) []byte {
+	t.Helper()
+
+	img := image.NewNRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			if x < width/2 {
+				img.Set(x, y, color.NRGBA{R: 255, A: 255})
+			} else {
+				img.Set(x, y, color.NRGBA{B: 255, A: 255})
+			}
+		}
+	}
+
+	var buffer bytes.Buffer
+	if e := encoder(&buffer, img); e != nil {
+		t.Fatalf("unexpected error: %v", e)
+	}
+
+	return buffer.Bytes()
+}
+
+func TestProcess(t *testing.T) {
+	data := sample(t, 2048, 1024, func(buffer *bytes.Buffer, img image.Image) error { return png.Encode(buffer, img) })
+
+	renditions, e := Process(data)
+	if e != nil {
+		t.Fatalf("unexpected error: %v", e)
+	}
+
+	if len(renditions) != 1+len(Thumbnails) {
+		t.Fatalf("expected %d rendition(s), got %d", 1+len(Thumbnails), len(renditions))
+	}
+
+	full := renditions[0]
+	if full.Name != "full" || full.Width != Dimension || full.Height != Dimension/2 || full.ContentType != "image/png" {
+		t.Errorf("unexpected full rendition: %s %dx%d %s", full.Name, full.Width, full.Height, full.ContentType)
+	}
+
+	for index, size := range Thumbnails {
+		rendition := renditions[1+index]
+		if rendition.Width != size || rendition.Height != size {
+			t.Errorf("expected a %dx%d thumbnail, got %dx%d", size, size, rendition.Width, rendition.Height)
+		}
+
+		decoded, e := png.Decode(bytes.NewReader(rendition.Data))
+		if e != nil {
+			t.Fatalf("unexpected error: %v", e)
+		}
+
+		// --> the center crop of a half red, half blue image remains half red, half blue
+		if r, _, b, _ := decoded.At(0, size/2).RGBA(); r>>8 != 255 || b != 0 {
+			t.Errorf("expected the thumbnail's left edge to be red, got r=%d b=%d", r>>8, b>>8)
+		}
+
+		if r, _, b, _ := decoded.At(size-1, size/2).RGBA(); b>>8 != 255 || r != 0 {
+			t.Errorf("expected the thumbnail's right edge to be blue, got r=%d b=%d", r>>8, b>>8)
+		}
+	}
+}
+
+func TestProcessJPEG(t *testing.T) {
+	data := sample(t, 100, 50, func(buffer *bytes.Buffer, img image.Image) error { return jpeg.Encode(buffer, img, nil) })
+
+	// --> append an EXIF segment after the SOI marker; re-encoding must discard it
+	exif := append([]byte{0xFF, 0xE1, 0x00, 0x10}, []byte("Exif\x00\x00GPS-DATA")...)
+	data = append(append(append([]byte{}, data[:2]...), exif...), data[2:]...)
+
+	renditions, e := Process(data)
+	if e != nil {
+		t.Fatalf("unexpected error: %v", e)
+	}
+
+	for _, rendition := range renditions {
+		if rendition.ContentType != "image/jpeg" || rendition.Extension != ".jpg" {
+			t.Errorf("expected a jpeg rendition, got %s", rendition.ContentType)
+		}
+
+		if bytes.Contains(rendition.Data, []byte("GPS-DATA")) {
+			t.Errorf("expected the %s rendition's metadata to be discarded", rendition.Name)
+		}
+	}
+
+	// --> small image(s) are never enlarged
+	if renditions[0].Width != 100 || renditions[0].Height != 50 {
+		t.Errorf("expected a 100x50 full rendition, got %dx%d", renditions[0].Width, renditions[0].Height)
+	}
+}
+
+func TestProcessInvalid(t *testing.T) {
+	tests := map[string]error{
+		"plain text": ErrUnsupportedType,
+		`<svg xmlns="http://www.w3.org/2000/svg"></svg>`: ErrUnsupportedType,
+		"\x89PNG\r\n\x1a\n truncated":                    ErrInvalidImage,
+	}
+
+	for input, expected := range tests {
+		if _, e := Process([]byte(input)); !(errors.Is(e, expected)) {
+			t.Errorf("expected %v for %q, got %v", expected, input, e)
+		}
+	}
+}
+
+func TestProcessTooLarge(t *testing.T) {
+	data := sample(t, 1, 1, func(buffer *bytes.Buffer, img image.Image) error { return png.Encode(buffer, img) })
+
+	// --> rewrite the IHDR chunk's dimension(s); only the header is decoded prior to the size check
+	binary.BigEndian.PutUint32(data[16:20], 4096)
+	binary.BigEndian.PutUint32(data[20:24], 4096)
+	binary.BigEndian.PutUint32(data[29:33], crc32.ChecksumIEEE(data[12:29]))
+
+	if _, e := Process(data); !(errors.Is(e, ErrTooLarge)) {
+		t.Errorf("expected %v, got %v", ErrTooLarge, e)
+	}
+}
+
+func TestScale(t *testing.T) {
+	// --> a transparent red pixel beside an opaque blue one, offset within the source's bounds
+	source := image.NewNRGBA(image.Rect(0, 0, 4, 3))
+	source.SetNRGBA(2, 1, color.NRGBA{R: 255, A: 0})
+	source.SetNRGBA(3, 1, color.NRGBA{B: 255, A: 255})
+
+	region := image.Rect(2, 1, 4, 2)
+
+	converted := image.NewRGBA(source.Bounds())
+	draw.Draw(converted, converted.Bounds(), source, image.Point{}, draw.Src)
+
+	for name, img := range map[string]image.Image{"NRGBA": source, "RGBA": converted} {
+		t.Run(name, func(t *testing.T) {
+			result := scale(img, region, 1, 1)
+
+			// --> premultiplied averaging; the transparent pixel's color mustn't bleed
+			if pixel := result.RGBAAt(0, 0); pixel != (color.RGBA{B: 127, A: 127}) {
+				t.Errorf("expected a half-transparent blue pixel, got %v", pixel)
+			}
+
+			// --> upscaling degrades to nearest-neighbor
+			enlarged := scale(img, region, 4, 2)
+			if left, right := enlarged.RGBAAt(0, 1), enlarged.RGBAAt(3, 0); left != (color.RGBA{}) || right != (color.RGBA{B: 255, A: 255}) {
+				t.Errorf("unexpected enlarged pixel(s): %v, %v", left, right)
+			}
+		})
+	}
+}
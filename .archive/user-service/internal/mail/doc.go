@@ -0,0 +1,3 @@
+// Package mail delivers outbound email through a pluggable [Sender]. [Log] and [File] implementations are provided for
+// local development, where no mail transfer agent is available.
+package mail
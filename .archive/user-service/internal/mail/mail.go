@@ -0,0 +1,79 @@
+package mail
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"log/slog"
+	"mime"
+	"net/mail"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Message represents a plain-text email.
+type Message struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// Sender delivers a [Message]. Implementations must be safe for concurrent use.
+type Sender interface {
+	Send(ctx context.Context, message *Message) error
+}
+
+// Log is a [Sender] that writes each message to the structured logger instead of delivering it.
+type Log struct{}
+
+func (Log) Send(ctx context.Context, message *Message) error {
+	slog.InfoContext(ctx, "Sending Email", slog.String("to", message.To), slog.String("subject", message.Subject), slog.String("body", message.Body))
+
+	return nil
+}
+
+// File is a [Sender] that writes each message as an RFC 5322 ".eml" file within the directory, e.g. for inspection via an
+// email client.
+type File struct {
+	Directory string
+	From      string // From represents the message's sender address. Defaults to "no-reply@localhost".
+}
+
+func (f *File) Send(ctx context.Context, message *Message) error {
+	if e := os.MkdirAll(f.Directory, 0o755); e != nil {
+		return fmt.Errorf("unable to create mail directory: %w", e)
+	}
+
+	from := f.From
+	if from == "" {
+		from = "no-reply@localhost"
+	}
+
+	suffix := make([]byte, 4)
+	if _, e := rand.Read(suffix); e != nil {
+		return e
+	}
+
+	now := time.Now()
+
+	var content strings.Builder
+	content.WriteString("From: " + (&mail.Address{Address: from}).String() + "\r\n")
+	content.WriteString("To: " + (&mail.Address{Address: message.To}).String() + "\r\n")
+	content.WriteString("Subject: " + mime.QEncoding.Encode("utf-8", message.Subject) + "\r\n")
+	content.WriteString("Date: " + now.Format(time.RFC1123Z) + "\r\n")
+	content.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+	content.WriteString("\r\n")
+	content.WriteString(strings.ReplaceAll(message.Body, "\n", "\r\n"))
+
+	name := filepath.Join(f.Directory, fmt.Sprintf("%s-%s.eml", now.UTC().Format("20060102T150405.000000000Z"), hex.EncodeToString(suffix)))
+	if e := os.WriteFile(name, []byte(content.String()), 0o644); e != nil {
+		return fmt.Errorf("unable to write mail file: %w", e)
+	}
+
+	slog.DebugContext(ctx, "Wrote Email to File", slog.String("to", message.To), slog.String("path", name))
+
+	return nil
+}
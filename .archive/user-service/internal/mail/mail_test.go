@@ -0,0 +1,36 @@
+package mail
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestFile(t *testing.T) {
+	directory := t.TempDir()
+
+	sender := &File{Directory: filepath.Join(directory, "outbox")}
+
+	message := &Message{To: "user@example.com", Subject: "Verify Your Email Address", Body: "Line One\nLine Two"}
+	if e := sender.Send(context.Background(), message); e != nil {
+		t.Fatalf("unexpected error: %v", e)
+	}
+
+	matches, e := filepath.Glob(filepath.Join(directory, "outbox", "*.eml"))
+	if e != nil || len(matches) != 1 {
+		t.Fatalf("expected exactly one .eml file, got %v (error: %v)", matches, e)
+	}
+
+	content, e := os.ReadFile(matches[0])
+	if e != nil {
+		t.Fatalf("unexpected error: %v", e)
+	}
+
+	for _, expected := range []string{"From: <no-reply@localhost>\r\n", "To: <user@example.com>\r\n", "Subject: Verify Your Email Address\r\n", "\r\n\r\nLine One\r\nLine Two"} {
+		if !(strings.Contains(string(content), expected)) {
+			t.Errorf("expected file to contain %q, got %q", expected, content)
+		}
+	}
+}
@@ -0,0 +1,105 @@
+package migration
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+	"text/tabwriter"
+	"time"
+)
+
+// Usage describes the "migrate" subcommand.
+const Usage = `Usage: migrate <command>
+
+Commands:
+  up                Apply all pending migration(s).
+  down [steps]      Revert the most recent migration(s); defaults to 1 step.
+  status            List every migration and whether it's applied.
+  force <version>   Record migration(s) up to version as applied without executing them; 0 clears all record(s).`
+
+// Command executes the "migrate" subcommand's argument(s) - see [Usage] - writing human-readable output.
+func Command(ctx context.Context, runner *Runner, arguments []string, output io.Writer) error {
+	if len(arguments) == 0 {
+		return fmt.Errorf("%w: missing command\n\n%s", ErrInvalidCommand, Usage)
+	}
+
+	switch command, arguments := arguments[0], arguments[1:]; command {
+	case "up":
+		if len(arguments) != 0 {
+			return fmt.Errorf("%w: up accepts no arguments\n\n%s", ErrInvalidCommand, Usage)
+		}
+
+		applied, e := runner.Up(ctx)
+		for _, migration := range applied {
+			fmt.Fprintf(output, "Applied %d (%s)\n", migration.Version, migration.Name)
+		}
+
+		if e == nil && len(applied) == 0 {
+			fmt.Fprintln(output, "No Pending Migration(s)")
+		}
+
+		return e
+	case "down":
+		steps := 1
+		if len(arguments) > 1 {
+			return fmt.Errorf("%w: down accepts at most one argument\n\n%s", ErrInvalidCommand, Usage)
+		} else if len(arguments) == 1 {
+			value, e := strconv.Atoi(arguments[0])
+			if e != nil || value < 1 {
+				return fmt.Errorf("%w: steps must be a positive integer, received %q", ErrInvalidCommand, arguments[0])
+			}
+
+			steps = value
+		}
+
+		reverted, e := runner.Down(ctx, steps)
+		for _, migration := range reverted {
+			fmt.Fprintf(output, "Reverted %d (%s)\n", migration.Version, migration.Name)
+		}
+
+		return e
+	case "status":
+		statuses, e := runner.Status(ctx)
+		if e != nil {
+			return e
+		}
+
+		writer := tabwriter.NewWriter(output, 0, 0, 2, ' ', 0)
+
+		fmt.Fprintln(writer, "VERSION\tNAME\tSTATUS\tAPPLIED")
+		for _, status := range statuses {
+			state, applied := "pending", "-"
+			if !(status.Applied.IsZero()) {
+				state, applied = "applied", status.Applied.UTC().Format(time.RFC3339)
+			}
+
+			if status.Unknown {
+				state = "unknown"
+			}
+
+			fmt.Fprintf(writer, "%d\t%s\t%s\t%s\n", status.Version, status.Name, state, applied)
+		}
+
+		return writer.Flush()
+	case "force":
+		if len(arguments) != 1 {
+			return fmt.Errorf("%w: force requires exactly one version\n\n%s", ErrInvalidCommand, Usage)
+		}
+
+		version, e := strconv.ParseInt(arguments[0], 10, 64)
+		if e != nil || version < 0 {
+			return fmt.Errorf("%w: version must be a non-negative integer, received %q", ErrInvalidCommand, arguments[0])
+		}
+
+		if e := runner.Force(ctx, version); e != nil {
+			return e
+		}
+
+		fmt.Fprintf(output, "Forced Version %d\n", version)
+
+		return nil
+	default:
+		return fmt.Errorf("%w: unknown command %q\n\n%s", ErrInvalidCommand, command, Usage)
+	}
+}
@@ -0,0 +1,4 @@
+// Package migration applies versioned, embedded SQL schema migration(s). Migration files are named
+// "{version}_{name}.up.sql" and "{version}_{name}.down.sql"; each is executed within its own transaction, alongside its
+// bookkeeping, while a PostgreSQL advisory lock serializes concurrently starting replicas.
+package migration
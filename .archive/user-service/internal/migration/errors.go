@@ -0,0 +1,9 @@
+package migration
+
+import "errors"
+
+var (
+	ErrInvalidMigration = errors.New("invalid migration")
+	ErrUnknownVersion   = errors.New("unknown migration version")
+	ErrInvalidCommand   = errors.New("invalid migrate command")
+)
@@ -0,0 +1,92 @@
+package migration
+
+import (
+	"fmt"
+	"io/fs"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+)
+
+// pattern matches migration file names, e.g. "000001_create-user.up.sql".
+var pattern = regexp.MustCompile(`^(\d+)_([^.]+)\.(up|down)\.sql$`)
+
+// Migration represents a single versioned schema change.
+type Migration struct {
+	Version int64
+	Name    string
+
+	Up   string // Up represents the SQL applying the migration.
+	Down string // Down represents the SQL reverting the migration.
+}
+
+// Load reads the migration(s) found anywhere within the file system, sorted by version. Every version must have exactly
+// one up and one down file; file(s) not matching the naming pattern are ignored.
+func Load(fsys fs.FS) ([]Migration, error) {
+	mapping := make(map[int64]*Migration)
+
+	e := fs.WalkDir(fsys, ".", func(name string, entry fs.DirEntry, e error) error {
+		if e != nil || entry.IsDir() {
+			return e
+		}
+
+		match := pattern.FindStringSubmatch(path.Base(name))
+		if match == nil {
+			return nil
+		}
+
+		version, e := strconv.ParseInt(match[1], 10, 64)
+		if e != nil || version <= 0 {
+			return fmt.Errorf("%w: %s: version must be a positive integer", ErrInvalidMigration, name)
+		}
+
+		content, e := fs.ReadFile(fsys, name)
+		if e != nil {
+			return e
+		}
+
+		migration, ok := mapping[version]
+		if !(ok) {
+			migration = &Migration{Version: version, Name: match[2]}
+			mapping[version] = migration
+		} else if migration.Name != match[2] {
+			return fmt.Errorf("%w: %s: version %d is already named %q", ErrInvalidMigration, name, version, migration.Name)
+		}
+
+		target := &migration.Up
+		if match[3] == "down" {
+			target = &migration.Down
+		}
+
+		if *target != "" {
+			return fmt.Errorf("%w: %s: duplicate %s migration for version %d", ErrInvalidMigration, name, match[3], version)
+		}
+
+		*target = string(content)
+
+		return nil
+	})
+
+	if e != nil {
+		return nil, e
+	}
+
+	migrations := make([]Migration, 0, len(mapping))
+	for _, migration := range mapping {
+		switch {
+		case migration.Up == "":
+			return nil, fmt.Errorf("%w: version %d (%s) is missing its up migration", ErrInvalidMigration, migration.Version, migration.Name)
+		case migration.Down == "":
+			return nil, fmt.Errorf("%w: version %d (%s) is missing its down migration", ErrInvalidMigration, migration.Version, migration.Name)
+		}
+
+		migrations = append(migrations, *migration)
+	}
+
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+
+	return migrations, nil
+}
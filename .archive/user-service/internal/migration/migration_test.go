@@ -0,0 +1,61 @@
+package migration
+
+import (
+	"errors"
+	"testing"
+	"testing/fstest"
+)
+
+func TestLoad(t *testing.T) {
+	fsys := fstest.MapFS{
+		"migrations/000002_add-index.up.sql":     {Data: []byte("CREATE INDEX ...;")},
+		"migrations/000002_add-index.down.sql":   {Data: []byte("DROP INDEX ...;")},
+		"migrations/000001_create-user.up.sql":   {Data: []byte("CREATE TABLE ...;")},
+		"migrations/000001_create-user.down.sql": {Data: []byte("DROP TABLE ...;")},
+		"migrations/README.md":                   {Data: []byte("ignored")},
+	}
+
+	migrations, e := Load(fsys)
+	if e != nil {
+		t.Fatalf("unexpected error: %v", e)
+	}
+
+	if len(migrations) != 2 {
+		t.Fatalf("expected 2 migrations, got %d", len(migrations))
+	}
+
+	if migrations[0].Version != 1 || migrations[0].Name != "create-user" || migrations[0].Up != "CREATE TABLE ...;" || migrations[0].Down != "DROP TABLE ...;" {
+		t.Errorf("unexpected first migration: %+v", migrations[0])
+	}
+
+	if migrations[1].Version != 2 || migrations[1].Name != "add-index" {
+		t.Errorf("unexpected second migration: %+v", migrations[1])
+	}
+}
+
+func TestLoadInvalid(t *testing.T) {
+	tests := map[string]fstest.MapFS{
+		"missing-down": {
+			"000001_create-user.up.sql": {Data: []byte("CREATE TABLE ...;")},
+		},
+		"missing-up": {
+			"000001_create-user.down.sql": {Data: []byte("DROP TABLE ...;")},
+		},
+		"conflicting-names": {
+			"000001_create-user.up.sql":    {Data: []byte("CREATE TABLE ...;")},
+			"000001_create-users.down.sql": {Data: []byte("DROP TABLE ...;")},
+		},
+		"zero-version": {
+			"0_create-user.up.sql":   {Data: []byte("CREATE TABLE ...;")},
+			"0_create-user.down.sql": {Data: []byte("DROP TABLE ...;")},
+		},
+	}
+
+	for name, fsys := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, e := Load(fsys); !(errors.Is(e, ErrInvalidMigration)) {
+				t.Errorf("expected ErrInvalidMigration, got %v", e)
+			}
+		})
+	}
+}
@@ -0,0 +1,32 @@
+package migration
+
+// Options is the configuration structure optionally mutated via the [Variadic] constructor used throughout the package.
+type Options struct {
+	Table string // Table represents the name of the table recording applied migration(s). Defaults to "schema-migration".
+	Lock  int64  // Lock represents the advisory lock key held while migrating. Defaults to a hash of [Options.Table].
+}
+
+// Variadic represents a functional constructor for the [Options] type. Typical callers of Variadic won't need to perform
+// nil checks as all implementations first construct an [Options] reference using packaged default(s).
+type Variadic func(o *Options)
+
+// options represents a default constructor.
+func options() *Options {
+	return &Options{
+		Table: "schema-migration",
+	}
+}
+
+// Table sets [Options.Table].
+func Table(table string) Variadic {
+	return func(o *Options) {
+		o.Table = table
+	}
+}
+
+// Lock sets [Options.Lock].
+func Lock(key int64) Variadic {
+	return func(o *Options) {
+		o.Lock = key
+	}
+}
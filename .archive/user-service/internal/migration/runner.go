@@ -0,0 +1,259 @@
+package migration
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"io/fs"
+	"log/slog"
+	"sort"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// Status represents a migration's state within the database.
+type Status struct {
+	Version int64
+	Name    string
+
+	Applied time.Time // Applied represents when the migration was applied; zero if pending.
+	Unknown bool      // Unknown is true if the version was applied but no longer exists in the source file system.
+}
+
+// Runner applies and reverts a fixed set of [Migration](s) against a connection pool.
+type Runner struct {
+	pool       *pgxpool.Pool
+	migrations []Migration
+	options    *Options
+
+	table string // table is the sanitized, quoted [Options.Table] identifier.
+}
+
+// New constructs a [Runner] for the migration(s) within the file system; see [Load].
+func New(pool *pgxpool.Pool, fsys fs.FS, settings ...Variadic) (*Runner, error) {
+	o := options()
+	for _, option := range settings {
+		option(o)
+	}
+
+	if o.Lock == 0 {
+		hash := fnv.New64a()
+		hash.Write([]byte(o.Table))
+
+		o.Lock = int64(hash.Sum64())
+	}
+
+	migrations, e := Load(fsys)
+	if e != nil {
+		return nil, e
+	}
+
+	return &Runner{pool: pool, migrations: migrations, options: o, table: pgx.Identifier{o.Table}.Sanitize()}, nil
+}
+
+// Up applies all pending migration(s) in version order, returning those applied. Applying stops at the first failure; as
+// each migration is transactional, the failed migration leaves no partial change(s) behind.
+func (r *Runner) Up(ctx context.Context) (applied []Migration, e error) {
+	e = r.locked(ctx, func(connection *pgxpool.Conn) error {
+		versions, e := r.applied(ctx, connection)
+		if e != nil {
+			return e
+		}
+
+		for _, migration := range r.migrations {
+			if _, ok := versions[migration.Version]; ok {
+				continue
+			}
+
+			start := time.Now()
+			if e := r.execute(ctx, connection, migration.Up, `INSERT INTO `+r.table+` ("version", "name") VALUES ($1, $2)`, migration.Version, migration.Name); e != nil {
+				return fmt.Errorf("unable to apply migration %d (%s): %w", migration.Version, migration.Name, e)
+			}
+
+			slog.InfoContext(ctx, "Applied Schema Migration", slog.Int64("version", migration.Version), slog.String("name", migration.Name), slog.Duration("duration", time.Since(start)))
+
+			applied = append(applied, migration)
+		}
+
+		return nil
+	})
+
+	return applied, e
+}
+
+// Down reverts the most recently applied migration(s), up to steps, returning those reverted.
+func (r *Runner) Down(ctx context.Context, steps int) (reverted []Migration, e error) {
+	if steps < 1 {
+		return nil, fmt.Errorf("%w: down requires at least one step", ErrInvalidCommand)
+	}
+
+	e = r.locked(ctx, func(connection *pgxpool.Conn) error {
+		versions, e := r.applied(ctx, connection)
+		if e != nil {
+			return e
+		}
+
+		for index := len(r.migrations) - 1; index >= 0 && len(reverted) < steps; index-- {
+			migration := r.migrations[index]
+			if _, ok := versions[migration.Version]; !(ok) {
+				continue
+			}
+
+			start := time.Now()
+			if e := r.execute(ctx, connection, migration.Down, `DELETE FROM `+r.table+` WHERE "version" = $1`, migration.Version); e != nil {
+				return fmt.Errorf("unable to revert migration %d (%s): %w", migration.Version, migration.Name, e)
+			}
+
+			slog.InfoContext(ctx, "Reverted Schema Migration", slog.Int64("version", migration.Version), slog.String("name", migration.Name), slog.Duration("duration", time.Since(start)))
+
+			reverted = append(reverted, migration)
+		}
+
+		return nil
+	})
+
+	return reverted, e
+}
+
+// Status reports every known migration's state, plus any applied version(s) missing from the source, sorted by version.
+func (r *Runner) Status(ctx context.Context) (statuses []Status, e error) {
+	e = r.locked(ctx, func(connection *pgxpool.Conn) error {
+		versions, e := r.applied(ctx, connection)
+		if e != nil {
+			return e
+		}
+
+		for _, migration := range r.migrations {
+			statuses = append(statuses, Status{Version: migration.Version, Name: migration.Name, Applied: versions[migration.Version].applied})
+			delete(versions, migration.Version)
+		}
+
+		for version, record := range versions {
+			statuses = append(statuses, Status{Version: version, Name: record.name, Applied: record.applied, Unknown: true})
+		}
+
+		return nil
+	})
+
+	sort.Slice(statuses, func(i, j int) bool {
+		return statuses[i].Version < statuses[j].Version
+	})
+
+	return statuses, e
+}
+
+// Force records exactly the migration(s) up to and including version as applied - without executing any SQL - such as
+// after manually repairing a failed migration. A version of 0 clears all record(s).
+func (r *Runner) Force(ctx context.Context, version int64) error {
+	known := version == 0
+	for _, migration := range r.migrations {
+		known = known || migration.Version == version
+	}
+
+	if !(known) {
+		return fmt.Errorf("%w: %d", ErrUnknownVersion, version)
+	}
+
+	return r.locked(ctx, func(connection *pgxpool.Conn) error {
+		return pgx.BeginFunc(ctx, connection, func(tx pgx.Tx) error {
+			if _, e := tx.Exec(ctx, `DELETE FROM `+r.table+` WHERE "version" > $1`, version); e != nil {
+				return e
+			}
+
+			for _, migration := range r.migrations {
+				if migration.Version > version {
+					break
+				}
+
+				if _, e := tx.Exec(ctx, `INSERT INTO `+r.table+` ("version", "name") VALUES ($1, $2) ON CONFLICT ("version") DO NOTHING`, migration.Version, migration.Name); e != nil {
+					return e
+				}
+			}
+
+			slog.WarnContext(ctx, "Forced Schema Migration Version", slog.Int64("version", version))
+
+			return nil
+		})
+	})
+}
+
+// record represents an applied migration's bookkeeping row.
+type record struct {
+	name    string
+	applied time.Time
+}
+
+// applied returns the recorded migration(s), keyed by version.
+func (r *Runner) applied(ctx context.Context, connection *pgxpool.Conn) (map[int64]record, error) {
+	rows, e := connection.Query(ctx, `SELECT "version", "name", "applied" FROM `+r.table)
+	if e != nil {
+		return nil, fmt.Errorf("unable to query applied migrations: %w", e)
+	}
+
+	defer rows.Close()
+
+	versions := make(map[int64]record)
+	for rows.Next() {
+		var version int64
+		var row record
+		if e := rows.Scan(&version, &row.name, &row.applied); e != nil {
+			return nil, fmt.Errorf("unable to scan applied migration: %w", e)
+		}
+
+		versions[version] = row
+	}
+
+	return versions, rows.Err()
+}
+
+// execute runs the migration script and its bookkeeping statement within a single transaction.
+func (r *Runner) execute(ctx context.Context, connection *pgxpool.Conn, script string, bookkeeping string, arguments ...interface{}) error {
+	return pgx.BeginFunc(ctx, connection, func(tx pgx.Tx) error {
+		// --> scripts are sent via the simple protocol, permitting multiple statement(s)
+		if _, e := tx.Exec(ctx, script, pgx.QueryExecModeSimpleProtocol); e != nil {
+			return e
+		}
+
+		_, e := tx.Exec(ctx, bookkeeping, arguments...)
+
+		return e
+	})
+}
+
+// locked acquires a dedicated connection, holds the session-level advisory lock - blocking until any concurrent runner
+// finishes - and ensures the bookkeeping table exists before calling fn.
+func (r *Runner) locked(ctx context.Context, fn func(connection *pgxpool.Conn) error) error {
+	connection, e := r.pool.Acquire(ctx)
+	if e != nil {
+		return fmt.Errorf("unable to acquire database connection: %w", e)
+	}
+
+	defer connection.Release()
+
+	if _, e := connection.Exec(ctx, "SELECT pg_advisory_lock($1)", r.options.Lock); e != nil {
+		return fmt.Errorf("unable to acquire migration lock: %w", e)
+	}
+
+	defer func() {
+		// --> the lock is session-scoped; a connection that can't unlock must not return to the pool still holding it
+		if _, e := connection.Exec(context.WithoutCancel(ctx), "SELECT pg_advisory_unlock($1)", r.options.Lock); e != nil {
+			slog.ErrorContext(ctx, "Unable to Release Migration Lock", slog.String("error", e.Error()))
+
+			connection.Conn().Close(context.WithoutCancel(ctx))
+		}
+	}()
+
+	statement := `CREATE TABLE IF NOT EXISTS ` + r.table + ` (
+		"version" bigint                   primary key,
+		"name"    text                     not null,
+		"applied" timestamp with time zone default now() not null
+	)`
+
+	if _, e := connection.Exec(ctx, statement); e != nil {
+		return fmt.Errorf("unable to create migration table: %w", e)
+	}
+
+	return fn(connection)
+}
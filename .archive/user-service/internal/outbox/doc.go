@@ -0,0 +1,4 @@
+// Package outbox implements the transactional outbox: domain event(s) are enqueued within the same database transaction
+// as the change they describe, and a [Relay] publishes them to the Redis stream afterwards. Delivery is at-least-once,
+// ordered per aggregate (user); consumer(s) must tolerate duplicate(s).
+package outbox
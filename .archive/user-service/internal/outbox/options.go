@@ -0,0 +1,57 @@
+package outbox
+
+import (
+	"time"
+)
+
+// Options is the configuration structure optionally mutated via the [Variadic] constructor used throughout the package.
+type Options struct {
+	Interval  time.Duration // Interval represents the duration between poll(s) absent a notification. Defaults to 5 seconds.
+	Batch     int32         // Batch represents the maximum number of event(s) relayed per transaction. Defaults to 100.
+	Retention time.Duration // Retention represents the duration a published event is retained prior to pruning. Defaults to 7 days.
+
+	Channel string // Channel represents the PostgreSQL notification channel signaled upon enqueue. Defaults to "outbox"; an empty value disables listening.
+}
+
+// Variadic represents a functional constructor for the [Options] type. Typical callers of Variadic won't need to perform
+// nil checks as all implementations first construct an [Options] reference using packaged default(s).
+type Variadic func(o *Options)
+
+// options represents a default constructor.
+func options() *Options {
+	return &Options{
+		Interval:  5 * time.Second,
+		Batch:     100,
+		Retention: 7 * 24 * time.Hour,
+
+		Channel: "outbox",
+	}
+}
+
+// Interval sets [Options.Interval].
+func Interval(duration time.Duration) Variadic {
+	return func(o *Options) {
+		o.Interval = duration
+	}
+}
+
+// Batch sets [Options.Batch].
+func Batch(size int32) Variadic {
+	return func(o *Options) {
+		o.Batch = size
+	}
+}
+
+// Retention sets [Options.Retention].
+func Retention(duration time.Duration) Variadic {
+	return func(o *Options) {
+		o.Retention = duration
+	}
+}
+
+// Channel sets [Options.Channel].
+func Channel(channel string) Variadic {
+	return func(o *Options) {
+		o.Channel = channel
+	}
+}
@@ -0,0 +1,66 @@
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"user-service/internal/events"
+	"user-service/models/users"
+)
+
+// message is a relayed [events.Event], reconstructed from its outbox row.
+type message struct {
+	kind   string
+	values map[string]interface{}
+}
+
+func (m *message) Type() string {
+	return m.kind
+}
+
+func (m *message) Values() map[string]interface{} {
+	return m.values
+}
+
+// encode serializes the event's field(s). Stream field(s) are strings on the wire, so each value is stringified upon
+// enqueue - avoiding json's lossy float64 representation of large integer(s) when relayed.
+func encode(event events.Event) ([]byte, error) {
+	var fields = make(map[string]string)
+	for key, value := range event.Values() {
+		fields[key] = fmt.Sprint(value)
+	}
+
+	return json.Marshal(fields)
+}
+
+// decode reconstructs the event from its outbox row.
+func decode(row *users.Outbox) (*message, error) {
+	var fields map[string]string
+	if e := json.Unmarshal(row.Payload, &fields); e != nil {
+		return nil, fmt.Errorf("invalid outbox payload (id: %d): %w", row.ID, e)
+	}
+
+	var values = make(map[string]interface{}, len(fields))
+	for key, value := range fields {
+		values[key] = value
+	}
+
+	return &message{kind: row.Type, values: values}, nil
+}
+
+// Enqueue writes the event to the outbox. The tx must be the transaction recording the change the event describes, so
+// that the event is published if - and only if - the change commits. The aggregate is the subject user's ID, by which
+// delivery is ordered.
+func Enqueue(ctx context.Context, tx users.DBTX, aggregate int64, event events.Event) error {
+	payload, e := encode(event)
+	if e != nil {
+		return fmt.Errorf("unable to encode %q event: %w", event.Type(), e)
+	}
+
+	if e := users.New().Enqueue(ctx, tx, &users.EnqueueParams{Aggregate: aggregate, Type: event.Type(), Payload: payload}); e != nil {
+		return fmt.Errorf("unable to enqueue %q event: %w", event.Type(), e)
+	}
+
+	return nil
+}
@@ -0,0 +1,134 @@
+package outbox
+
+import (
+	"context"
+	"math"
+	"os"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"user-service/internal/database"
+	"user-service/internal/events"
+	"user-service/internal/migration"
+	"user-service/models/users"
+)
+
+func TestEncode(t *testing.T) {
+	creation := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	event := &events.Registration{ID: math.MaxInt64, Email: "user@example.com", Creation: creation, Verification: "http://localhost:8080/verify?token=abc"}
+
+	payload, e := encode(event)
+	if e != nil {
+		t.Fatalf("unexpected error: %v", e)
+	}
+
+	message, e := decode(&users.Outbox{ID: 1, Aggregate: event.ID, Type: event.Type(), Payload: payload})
+	if e != nil {
+		t.Fatalf("unexpected error: %v", e)
+	}
+
+	if message.Type() != "registration" {
+		t.Errorf("expected type \"registration\", got %q", message.Type())
+	}
+
+	expected := map[string]string{
+		"id":           "9223372036854775807",
+		"email":        "user@example.com",
+		"creation":     "2024-01-02T03:04:05Z",
+		"verification": "http://localhost:8080/verify?token=abc",
+	}
+
+	values := message.Values()
+	if len(values) != len(expected) {
+		t.Errorf("expected %d value(s), got %d: %v", len(expected), len(values), values)
+	}
+
+	for key, value := range expected {
+		if values[key] != value {
+			t.Errorf("expected %s %q, got %v", key, value, values[key])
+		}
+	}
+}
+
+func TestDecodeInvalid(t *testing.T) {
+	if _, e := decode(&users.Outbox{ID: 1, Type: "registration", Payload: []byte(`[1, 2]`)}); e == nil {
+		t.Errorf("expected an error for a non-object payload")
+	}
+}
+
+// recorder is an in-memory [events.Publisher].
+type recorder struct {
+	mutex  sync.Mutex
+	events []events.Event
+}
+
+func (r *recorder) Publish(ctx context.Context, event events.Event) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.events = append(r.events, event)
+
+	return nil
+}
+
+// TestDrainSequential requires a disposable PostgreSQL database, specified by the "DATABASE_TEST_DSN" environment variable.
+func TestDrainSequential(t *testing.T) {
+	dsn := os.Getenv("DATABASE_TEST_DSN")
+	if dsn == "" {
+		t.Skip("DATABASE_TEST_DSN not set")
+	}
+
+	ctx := context.Background()
+
+	pool, e := database.Pool(ctx, database.DSN(dsn))
+	if e != nil {
+		t.Fatalf("unexpected error: %v", e)
+	}
+
+	defer pool.Close()
+
+	runner, e := migration.New(pool, users.Migrations)
+	if e != nil {
+		t.Fatalf("unexpected error: %v", e)
+	}
+
+	if _, e := runner.Up(ctx); e != nil {
+		t.Fatalf("unexpected error: %v", e)
+	}
+
+	// --> a single aggregate's event(s) become eligible one at a time, so each pass relays a batch far short of the limit
+	const total = 3
+
+	aggregate := time.Now().UnixNano()
+	e = database.WithTx(ctx, pool, nil, func(tx users.DBTX) error {
+		for index := 0; index < total; index++ {
+			if e := Enqueue(ctx, tx, aggregate, &events.Update{ID: aggregate, Fields: []string{"name"}}); e != nil {
+				return e
+			}
+		}
+
+		return nil
+	})
+
+	if e != nil {
+		t.Fatalf("unexpected error: %v", e)
+	}
+
+	publisher := &recorder{}
+	if e := New(pool, publisher, Batch(10)).drain(ctx); e != nil {
+		t.Fatalf("unexpected error: %v", e)
+	}
+
+	var relayed int
+	for _, event := range publisher.events {
+		if event.Values()["id"] == strconv.FormatInt(aggregate, 10) {
+			relayed++
+		}
+	}
+
+	if relayed != total {
+		t.Errorf("expected %d relayed event(s), got %d", total, relayed)
+	}
+}
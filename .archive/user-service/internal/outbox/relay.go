@@ -0,0 +1,203 @@
+package outbox
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"user-service/internal/database"
+	"user-service/internal/events"
+	"user-service/models/users"
+)
+
+// Relay publishes enqueued outbox event(s). Any number of relay(s) - e.g. one per replica - may run concurrently.
+type Relay struct {
+	pool      *pgxpool.Pool
+	publisher events.Publisher
+
+	options *Options
+}
+
+// New constructs a [Relay].
+func New(pool *pgxpool.Pool, publisher events.Publisher, settings ...Variadic) *Relay {
+	o := options()
+	for _, option := range settings {
+		option(o)
+	}
+
+	if o.Batch < 1 {
+		o.Batch = options().Batch
+	}
+
+	return &Relay{pool: pool, publisher: publisher, options: o}
+}
+
+// Run relays pending event(s) upon each notification - or every [Options.Interval] - until the context is canceled.
+// Published event(s) are pruned after [Options.Retention].
+func (r *Relay) Run(ctx context.Context) {
+	wake := make(chan struct{}, 1)
+	if r.options.Channel != "" {
+		go r.listen(ctx, wake)
+	}
+
+	ticker := time.NewTicker(r.options.Interval)
+	defer ticker.Stop()
+
+	for {
+		if e := r.drain(ctx); e != nil && ctx.Err() == nil {
+			slog.ErrorContext(ctx, "Unable to Relay Outbox Event(s)", slog.String("error", e.Error()))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-wake:
+		case <-ticker.C:
+			if e := r.prune(ctx); e != nil && ctx.Err() == nil {
+				slog.ErrorContext(ctx, "Unable to Prune Outbox Event(s)", slog.String("error", e.Error()))
+			}
+		}
+	}
+}
+
+// listen holds a dedicated connection subscribed to [Options.Channel], signaling wake upon each notification. The
+// connection is re-established after [Options.Interval] upon failure; polling continues meanwhile.
+func (r *Relay) listen(ctx context.Context, wake chan<- struct{}) {
+	for ctx.Err() == nil {
+		if e := r.subscribe(ctx, wake); e != nil && ctx.Err() == nil {
+			slog.WarnContext(ctx, "Outbox Notification Listener Failed", slog.String("error", e.Error()))
+		}
+
+		select {
+		case <-ctx.Done():
+		case <-time.After(r.options.Interval):
+		}
+	}
+}
+
+// subscribe performs a single [Relay.listen] session.
+func (r *Relay) subscribe(ctx context.Context, wake chan<- struct{}) error {
+	acquired, e := r.pool.Acquire(ctx)
+	if e != nil {
+		return fmt.Errorf("unable to acquire database connection: %w", e)
+	}
+
+	// --> a subscribed connection must never return to the pool; it's closed rather than released
+	connection := acquired.Hijack()
+
+	defer connection.Close(context.WithoutCancel(ctx))
+
+	if _, e := connection.Exec(ctx, "LISTEN "+pgx.Identifier{r.options.Channel}.Sanitize()); e != nil {
+		return fmt.Errorf("unable to listen on channel %q: %w", r.options.Channel, e)
+	}
+
+	for {
+		if _, e := connection.WaitForNotification(ctx); e != nil {
+			return e
+		}
+
+		// --> non-blocking; a pending signal already guarantees another drain
+		select {
+		case wake <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// drain relays batch(es) of pending event(s) until a pass makes no progress.
+func (r *Relay) drain(ctx context.Context) error {
+	for {
+		count, e := r.relay(ctx)
+		if e != nil {
+			return e
+		}
+
+		// --> a short batch doesn't imply an empty outbox: only each aggregate's earliest event is eligible, so publishing
+		// it may have made its successor eligible
+		if count == 0 {
+			return nil
+		}
+	}
+}
+
+// relay publishes a single batch within a transaction, marking each successfully published event. A publishing failure
+// stops the batch; the event(s) already published are still marked, and the remainder retried upon the next drain.
+func (r *Relay) relay(ctx context.Context) (int, error) {
+	var count int
+	var failure error
+
+	e := database.WithTx(ctx, r.pool, nil, func(tx users.DBTX) error {
+		count, failure = 0, nil
+
+		pending, e := users.New().Pending(ctx, tx, r.options.Batch)
+		if e != nil {
+			return fmt.Errorf("unable to select pending outbox event(s): %w", e)
+		}
+
+		var published = make([]int64, 0, len(pending))
+		for index := range pending {
+			row := &pending[index]
+
+			event, e := decode(row)
+			if e == nil {
+				e = r.publisher.Publish(ctx, event)
+			}
+
+			if e != nil {
+				failure = fmt.Errorf("unable to publish outbox event (id: %d, type: %q, aggregate: %d): %w", row.ID, row.Type, row.Aggregate, e)
+				break
+			}
+
+			published = append(published, row.ID)
+		}
+
+		if len(published) == 0 {
+			return nil
+		}
+
+		// --> a failed commit re-publishes the batch upon the next drain: delivery is at-least-once
+		if e := users.New().MarkPublished(ctx, tx, published); e != nil {
+			return fmt.Errorf("unable to mark outbox event(s) as published: %w", e)
+		}
+
+		count = len(published)
+
+		return nil
+	})
+
+	if e != nil {
+		return 0, e
+	}
+
+	if count > 0 {
+		slog.DebugContext(ctx, "Relayed Outbox Event(s)", slog.Int("count", count))
+	}
+
+	// --> a publishing failure ends the drain, deferring the failed event until the next notification or poll
+	if failure != nil {
+		return 0, failure
+	}
+
+	return count, nil
+}
+
+// prune deletes event(s) published longer than [Options.Retention] ago.
+func (r *Relay) prune(ctx context.Context) error {
+	cutoff := pgtype.Timestamptz{Time: time.Now().Add(-r.options.Retention), Valid: true}
+
+	count, e := users.New().Prune(ctx, r.pool, cutoff)
+	if e != nil {
+		return e
+	}
+
+	if count > 0 {
+		slog.DebugContext(ctx, "Pruned Published Outbox Event(s)", slog.Int64("count", count))
+	}
+
+	return nil
+}
@@ -0,0 +1,3 @@
+// Package retention governs soft-deleted user account(s): restoring them within a grace period, and periodically purging
+// those past retention - publishing a "user.deleted" event for each, so dependent service(s) can erase their copies.
+package retention
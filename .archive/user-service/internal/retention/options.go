@@ -0,0 +1,57 @@
+package retention
+
+import (
+	"time"
+)
+
+// Options is the configuration structure optionally mutated via the [Variadic] constructor used throughout the package.
+type Options struct {
+	Grace     time.Duration // Grace represents the duration a soft-deleted user remains restorable. Defaults to 14 days; never exceeds Retention.
+	Retention time.Duration // Retention represents the duration a soft-deleted user is retained prior to being purged. Defaults to 30 days.
+
+	Interval time.Duration // Interval represents the duration between [Policy.Sweep] pass(es). Defaults to 1 hour.
+	Batch    int32         // Batch represents the maximum number of user(s) purged per transaction. Defaults to 100.
+}
+
+// Variadic represents a functional constructor for the [Options] type. Typical callers of Variadic won't need to perform
+// nil checks as all implementations first construct an [Options] reference using packaged default(s).
+type Variadic func(o *Options)
+
+// options represents a default constructor.
+func options() *Options {
+	return &Options{
+		Grace:     14 * 24 * time.Hour,
+		Retention: 30 * 24 * time.Hour,
+
+		Interval: time.Hour,
+		Batch:    100,
+	}
+}
+
+// Grace sets [Options.Grace].
+func Grace(duration time.Duration) Variadic {
+	return func(o *Options) {
+		o.Grace = duration
+	}
+}
+
+// Retention sets [Options.Retention].
+func Retention(duration time.Duration) Variadic {
+	return func(o *Options) {
+		o.Retention = duration
+	}
+}
+
+// Interval sets [Options.Interval].
+func Interval(duration time.Duration) Variadic {
+	return func(o *Options) {
+		o.Interval = duration
+	}
+}
+
+// Batch sets [Options.Batch].
+func Batch(size int32) Variadic {
+	return func(o *Options) {
+		o.Batch = size
+	}
+}
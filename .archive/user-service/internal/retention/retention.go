@@ -0,0 +1,146 @@
+package retention
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"user-service/internal/database"
+	"user-service/internal/events"
+	"user-service/internal/outbox"
+	"user-service/models/users"
+)
+
+// lock is the transaction-scoped advisory lock key held during a [Policy.Sweep] pass, so only one replica purges at a time.
+const lock int64 = 0x7573657270757267 // --> "userpurg"
+
+var (
+	ErrNotFound   = errors.New("user not found")
+	ErrNotDeleted = errors.New("user is not deleted")
+	ErrExpired    = errors.New("user's restoration grace period has elapsed")
+	ErrConflict   = errors.New("user's email address belongs to another active user")
+)
+
+// Policy restores and purges soft-deleted user(s).
+type Policy struct {
+	options *Options
+}
+
+// New constructs a [Policy].
+func New(settings ...Variadic) *Policy {
+	o := options()
+	for _, option := range settings {
+		option(o)
+	}
+
+	// --> a purged user can't be restored, regardless of the grace period
+	o.Grace = min(o.Grace, o.Retention)
+
+	if o.Batch < 1 {
+		o.Batch = options().Batch
+	}
+
+	return &Policy{options: o}
+}
+
+// Restore reverts the user's soft-deletion within the grace period, returning the restored user.
+func (p *Policy) Restore(ctx context.Context, tx users.DBTX, id int64) (users.User, error) {
+	cutoff := pgtype.Timestamptz{Time: time.Now().Add(-p.options.Grace), Valid: true}
+
+	user, e := users.New().Restore(ctx, tx, &users.RestoreParams{ID: id, Cutoff: cutoff})
+	switch {
+	case database.Unique(e, users.EmailUniqueIndex):
+		return users.User{}, ErrConflict
+	case errors.Is(e, pgx.ErrNoRows):
+		// --> distinguish the failure for the caller
+		user, e := users.New().Lookup(ctx, tx, id)
+		switch {
+		case errors.Is(e, pgx.ErrNoRows):
+			return users.User{}, ErrNotFound
+		case e != nil:
+			return users.User{}, fmt.Errorf("unable to lookup user: %w", e)
+		case !(user.Deletion.Valid):
+			return users.User{}, ErrNotDeleted
+		}
+
+		return users.User{}, ErrExpired
+	case e != nil:
+		return users.User{}, fmt.Errorf("unable to restore user: %w", e)
+	}
+
+	return user, nil
+}
+
+// Sweep purges user(s) soft-deleted longer than [Options.Retention] every [Options.Interval], until the context is canceled.
+// Concurrent sweepers - e.g. one per replica - coordinate via an advisory lock.
+func (p *Policy) Sweep(ctx context.Context, pool *pgxpool.Pool) {
+	ticker := time.NewTicker(p.options.Interval)
+	defer ticker.Stop()
+
+	for {
+		if e := p.sweep(ctx, pool); e != nil && ctx.Err() == nil {
+			slog.ErrorContext(ctx, "Unable to Purge Deleted User(s)", slog.String("error", e.Error()))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// sweep performs a single [Policy.Sweep] pass, purging in batches of [Options.Batch].
+func (p *Policy) sweep(ctx context.Context, pool *pgxpool.Pool) error {
+	cutoff := pgtype.Timestamptz{Time: time.Now().Add(-p.options.Retention), Valid: true}
+
+	for {
+		var count int
+		e := database.WithTx(ctx, pool, nil, func(tx users.DBTX) error {
+			count = 0
+
+			var acquired bool
+			if e := tx.QueryRow(ctx, "SELECT pg_try_advisory_xact_lock($1)", lock).Scan(&acquired); e != nil {
+				return e
+			} else if !(acquired) {
+				slog.DebugContext(ctx, "Deleted User Purge Already in Progress")
+				return nil
+			}
+
+			purged, e := users.New().Purge(ctx, tx, &users.PurgeParams{Cutoff: cutoff, Size: p.options.Batch})
+			if e != nil {
+				return e
+			}
+
+			// --> enqueued within the transaction; a lost event would leave dependent service(s) holding the user's data indefinitely
+			for index := range purged {
+				event := &events.Deletion{ID: purged[index].ID, Email: purged[index].Email, Deletion: purged[index].Deletion.Time}
+				if e := outbox.Enqueue(ctx, tx, purged[index].ID, event); e != nil {
+					return e
+				}
+			}
+
+			count = len(purged)
+
+			return nil
+		})
+
+		if e != nil {
+			return e
+		}
+
+		if count > 0 {
+			slog.InfoContext(ctx, "Purged Deleted User(s)", slog.Int("count", count))
+		}
+
+		if count < int(p.options.Batch) {
+			return nil
+		}
+	}
+}
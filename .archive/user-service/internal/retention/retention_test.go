@@ -0,0 +1,248 @@
+package retention
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"user-service/internal/database"
+	"user-service/internal/migration"
+	"user-service/models/users"
+)
+
+func TestNew(t *testing.T) {
+	p := New(Grace(60*24*time.Hour), Retention(30*24*time.Hour), Batch(0))
+
+	if p.options.Grace != p.options.Retention {
+		t.Errorf("expected the grace period to be clamped to the retention period, got %s", p.options.Grace)
+	}
+
+	if p.options.Batch != options().Batch {
+		t.Errorf("expected the default batch size, got %d", p.options.Batch)
+	}
+}
+
+// connect requires a disposable PostgreSQL database, specified by the "DATABASE_TEST_DSN" environment variable.
+func connect(t *testing.T) *pgxpool.Pool {
+	t.Helper()
+
+	dsn := os.Getenv("DATABASE_TEST_DSN")
+	if dsn == "" {
+		t.Skip("DATABASE_TEST_DSN not set")
+	}
+
+	ctx := context.Background()
+
+	pool, e := database.Pool(ctx, database.DSN(dsn))
+	if e != nil {
+		t.Fatalf("unexpected error: %v", e)
+	}
+
+	t.Cleanup(pool.Close)
+
+	runner, e := migration.New(pool, users.Migrations)
+	if e != nil {
+		t.Fatalf("unexpected error: %v", e)
+	}
+
+	if _, e := runner.Up(ctx); e != nil {
+		t.Fatalf("unexpected error: %v", e)
+	}
+
+	return pool
+}
+
+// create inserts an active user, removing it - and its outbox event(s) - once the test completes.
+func create(t *testing.T, pool *pgxpool.Pool, email string) users.User {
+	t.Helper()
+
+	user, e := users.New().Create(context.Background(), pool, &users.CreateParams{Email: email})
+	if e != nil {
+		t.Fatalf("unexpected error: %v", e)
+	}
+
+	t.Cleanup(func() {
+		ctx := context.Background()
+
+		if _, e := pool.Exec(ctx, `DELETE FROM "User" WHERE (id) = $1`, user.ID); e != nil {
+			t.Errorf("unable to remove test user: %v", e)
+		}
+
+		if _, e := pool.Exec(ctx, `DELETE FROM "Outbox" WHERE (aggregate) = $1`, user.ID); e != nil {
+			t.Errorf("unable to remove test outbox event(s): %v", e)
+		}
+	})
+
+	return user
+}
+
+// remove soft-deletes the user, backdating the deletion by age.
+func remove(t *testing.T, pool *pgxpool.Pool, id int64, age time.Duration) {
+	t.Helper()
+
+	ctx := context.Background()
+
+	if _, e := users.New().SoftDelete(ctx, pool, id); e != nil {
+		t.Fatalf("unexpected error: %v", e)
+	}
+
+	if _, e := pool.Exec(ctx, `UPDATE "User" SET deletion = $2 WHERE (id) = $1`, id, time.Now().Add(-age)); e != nil {
+		t.Fatalf("unexpected error: %v", e)
+	}
+}
+
+func email(label string) string {
+	return fmt.Sprintf("retention-%s-%d@example.com", label, time.Now().UnixNano())
+}
+
+// TestSoftDelete requires a disposable PostgreSQL database, specified by the "DATABASE_TEST_DSN" environment variable.
+func TestSoftDelete(t *testing.T) {
+	pool := connect(t)
+
+	ctx := context.Background()
+
+	user := create(t, pool, email("soft-delete"))
+
+	deleted, e := users.New().SoftDelete(ctx, pool, user.ID)
+	if e != nil {
+		t.Fatalf("unexpected error: %v", e)
+	}
+
+	if !(deleted.Deletion.Valid) {
+		t.Errorf("expected a deletion timestamp")
+	}
+
+	// --> a deleted user is retained, but no longer active
+	if _, e := users.New().Profile(ctx, pool, user.Email); !(errors.Is(e, pgx.ErrNoRows)) {
+		t.Errorf("expected %v for a deleted user's profile, got %v", pgx.ErrNoRows, e)
+	}
+
+	if _, e := users.New().Lookup(ctx, pool, user.ID); e != nil {
+		t.Errorf("expected a deleted user to be retained, got %v", e)
+	}
+
+	if _, e := users.New().SoftDelete(ctx, pool, user.ID); !(errors.Is(e, pgx.ErrNoRows)) {
+		t.Errorf("expected %v for a repeated deletion, got %v", pgx.ErrNoRows, e)
+	}
+}
+
+// TestRestore requires a disposable PostgreSQL database, specified by the "DATABASE_TEST_DSN" environment variable.
+func TestRestore(t *testing.T) {
+	pool := connect(t)
+
+	ctx := context.Background()
+
+	policy := New(Grace(time.Hour), Retention(2*time.Hour))
+
+	user := create(t, pool, email("restore"))
+
+	remove(t, pool, user.ID, time.Minute)
+
+	restored, e := policy.Restore(ctx, pool, user.ID)
+	if e != nil {
+		t.Fatalf("unexpected error: %v", e)
+	}
+
+	if restored.ID != user.ID || restored.Deletion.Valid {
+		t.Errorf("expected an active user, got %+v", restored)
+	}
+
+	if _, e := policy.Restore(ctx, pool, user.ID); !(errors.Is(e, ErrNotDeleted)) {
+		t.Errorf("expected %v, got %v", ErrNotDeleted, e)
+	}
+
+	if _, e := policy.Restore(ctx, pool, math.MaxInt64); !(errors.Is(e, ErrNotFound)) {
+		t.Errorf("expected %v, got %v", ErrNotFound, e)
+	}
+
+	// --> past the grace period, yet not purged
+	remove(t, pool, user.ID, 90*time.Minute)
+
+	if _, e := policy.Restore(ctx, pool, user.ID); !(errors.Is(e, ErrExpired)) {
+		t.Errorf("expected %v, got %v", ErrExpired, e)
+	}
+}
+
+// TestRestoreConflict requires a disposable PostgreSQL database, specified by the "DATABASE_TEST_DSN" environment variable.
+func TestRestoreConflict(t *testing.T) {
+	pool := connect(t)
+
+	ctx := context.Background()
+
+	address := email("conflict")
+
+	original := create(t, pool, address)
+
+	remove(t, pool, original.ID, time.Minute)
+
+	// --> the address was re-registered by another account during the grace period
+	create(t, pool, address)
+
+	if _, e := New().Restore(ctx, pool, original.ID); !(errors.Is(e, ErrConflict)) {
+		t.Errorf("expected %v, got %v", ErrConflict, e)
+	}
+}
+
+// TestSweep requires a disposable PostgreSQL database, specified by the "DATABASE_TEST_DSN" environment variable.
+func TestSweep(t *testing.T) {
+	pool := connect(t)
+
+	ctx := context.Background()
+
+	// --> a batch size of 1 exercises multiple purge transaction(s) per pass
+	policy := New(Grace(time.Hour), Retention(2*time.Hour), Batch(1))
+
+	expired := []users.User{create(t, pool, email("expired")), create(t, pool, email("expired"))}
+	for _, user := range expired {
+		remove(t, pool, user.ID, 3*time.Hour)
+	}
+
+	retained := create(t, pool, email("retained"))
+	remove(t, pool, retained.ID, 90*time.Minute)
+
+	active := create(t, pool, email("active"))
+
+	if e := policy.sweep(ctx, pool); e != nil {
+		t.Fatalf("unexpected error: %v", e)
+	}
+
+	for _, user := range expired {
+		if _, e := users.New().Lookup(ctx, pool, user.ID); !(errors.Is(e, pgx.ErrNoRows)) {
+			t.Errorf("expected user %d to be purged, got %v", user.ID, e)
+		}
+
+		var kind string
+		var payload []byte
+		if e := pool.QueryRow(ctx, `SELECT type, payload FROM "Outbox" WHERE (aggregate) = $1`, user.ID).Scan(&kind, &payload); e != nil {
+			t.Fatalf("expected a deletion event for user %d, got %v", user.ID, e)
+		}
+
+		var fields map[string]string
+		if e := json.Unmarshal(payload, &fields); e != nil {
+			t.Fatalf("unexpected error: %v", e)
+		}
+
+		if kind != "user.deleted" || fields["email"] != user.Email || fields["id"] != fmt.Sprint(user.ID) || fields["deletion"] == "" {
+			t.Errorf("unexpected deletion event: %s %v", kind, fields)
+		}
+	}
+
+	for _, user := range []users.User{retained, active} {
+		if _, e := users.New().Lookup(ctx, pool, user.ID); e != nil {
+			t.Errorf("expected user %d to be retained, got %v", user.ID, e)
+		}
+
+		var count int
+		if e := pool.QueryRow(ctx, `SELECT count(*) FROM "Outbox" WHERE (aggregate) = $1`, user.ID).Scan(&count); e != nil || count != 0 {
+			t.Errorf("expected no event(s) for user %d, got %d (%v)", user.ID, count, e)
+		}
+	}
+}
@@ -0,0 +1,3 @@
+// Package storage persists public object(s), such as avatar image(s), behind the [Storage] interface - backed by either the
+// local filesystem or an S3-compatible object store (e.g. AWS S3, or MinIO as a local stand-in).
+package storage
@@ -0,0 +1,189 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+)
+
+// S3 is a [Storage] backed by an S3-compatible object store, authenticated via AWS Signature Version 4. Objects are
+// addressed path-style ("{Endpoint}/{Bucket}/{key}"), which S3-compatible stand-in(s) such as MinIO support by default.
+type S3 struct {
+	Endpoint string // Endpoint represents the object store's base URL, e.g. "https://s3.us-east-1.amazonaws.com" or "http://minio:9000".
+	Region   string // Region represents the signing region. Defaults to "us-east-1".
+	Bucket   string
+
+	AccessKey string
+	SecretKey string
+
+	Public string // Public, if non-empty, represents the base URL object(s) are publicly served from, e.g. a CDN. Defaults to the path-style bucket URL.
+
+	Client *http.Client // Client, if non-nil, overrides [http.DefaultClient].
+}
+
+func (s *S3) Put(ctx context.Context, key string, data []byte, contentType string) error {
+	if e := validate(key); e != nil {
+		return e
+	}
+
+	request, e := http.NewRequestWithContext(ctx, http.MethodPut, s.object(key), bytes.NewReader(data))
+	if e != nil {
+		return e
+	}
+
+	request.Header.Set("Content-Type", contentType)
+
+	if e := s.do(request, data); e != nil {
+		return fmt.Errorf("unable to put object %q: %w", key, e)
+	}
+
+	slog.DebugContext(ctx, "Stored Object", slog.String("key", key), slog.String("bucket", s.Bucket), slog.String("content-type", contentType))
+
+	return nil
+}
+
+func (s *S3) Delete(ctx context.Context, key string) error {
+	if e := validate(key); e != nil {
+		return e
+	}
+
+	request, e := http.NewRequestWithContext(ctx, http.MethodDelete, s.object(key), nil)
+	if e != nil {
+		return e
+	}
+
+	if e := s.do(request, nil); e != nil {
+		return fmt.Errorf("unable to delete object %q: %w", key, e)
+	}
+
+	return nil
+}
+
+func (s *S3) URL(key string) string {
+	if s.Public != "" {
+		return strings.TrimSuffix(s.Public, "/") + "/" + key
+	}
+
+	return s.object(key)
+}
+
+// object returns the object's path-style URL.
+func (s *S3) object(key string) string {
+	return strings.TrimSuffix(s.Endpoint, "/") + "/" + escape(s.Bucket) + "/" + escape(key)
+}
+
+// do signs and sends the request, returning an error for a non-2xx response.
+func (s *S3) do(request *http.Request, payload []byte) error {
+	s.sign(request, payload, time.Now())
+
+	client := s.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	response, e := client.Do(request)
+	if e != nil {
+		return e
+	}
+
+	defer response.Body.Close()
+
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		body, _ := io.ReadAll(io.LimitReader(response.Body, 1024))
+
+		return fmt.Errorf("unexpected response status %d: %s", response.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	return nil
+}
+
+// sign adds the AWS Signature Version 4 "Authorization" header - and the "X-Amz-Date" and "X-Amz-Content-Sha256" header(s)
+// it covers - to the request.
+func (s *S3) sign(request *http.Request, payload []byte, now time.Time) {
+	region := s.Region
+	if region == "" {
+		region = "us-east-1"
+	}
+
+	now = now.UTC()
+	timestamp := now.Format("20060102T150405Z")
+	date := now.Format("20060102")
+	digest := sha256.Sum256(payload)
+
+	request.Header.Set("X-Amz-Date", timestamp)
+	request.Header.Set("X-Amz-Content-Sha256", hex.EncodeToString(digest[:]))
+
+	// --> the host header isn't part of request.Header, but must be signed
+	var headers = map[string]string{"host": request.URL.Host}
+	for key, values := range request.Header {
+		headers[strings.ToLower(key)] = strings.TrimSpace(strings.Join(values, ","))
+	}
+
+	var names = make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	var canonical strings.Builder
+	for _, name := range names {
+		canonical.WriteString(name + ":" + headers[name] + "\n")
+	}
+
+	signed := strings.Join(names, ";")
+
+	path := request.URL.EscapedPath()
+	if path == "" {
+		path = "/"
+	}
+
+	request.URL.RawQuery = strings.ReplaceAll(request.URL.Query().Encode(), "+", "%20")
+
+	hashed := sha256.Sum256([]byte(strings.Join([]string{request.Method, path, request.URL.RawQuery, canonical.String(), signed, hex.EncodeToString(digest[:])}, "\n")))
+
+	scope := date + "/" + region + "/s3/aws4_request"
+	message := "AWS4-HMAC-SHA256\n" + timestamp + "\n" + scope + "\n" + hex.EncodeToString(hashed[:])
+
+	key := mac([]byte("AWS4"+s.SecretKey), date)
+	key = mac(key, region)
+	key = mac(key, "s3")
+	key = mac(key, "aws4_request")
+
+	signature := hex.EncodeToString(mac(key, message))
+
+	request.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s", s.AccessKey, scope, signed, signature))
+}
+
+// mac computes HMAC-SHA256.
+func mac(key []byte, message string) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(message))
+
+	return h.Sum(nil)
+}
+
+// escape URI-encodes the slash-separated path per AWS Signature Version 4's canonical URI rule(s): every byte other than
+// an unreserved character - or "/" - is percent-encoded.
+func escape(value string) string {
+	var builder strings.Builder
+	for index := 0; index < len(value); index++ {
+		switch c := value[index]; {
+		case 'A' <= c && c <= 'Z', 'a' <= c && c <= 'z', '0' <= c && c <= '9', c == '-', c == '_', c == '.', c == '~', c == '/':
+			builder.WriteByte(c)
+		default:
+			fmt.Fprintf(&builder, "%%%02X", c)
+		}
+	}
+
+	return builder.String()
+}
@@ -0,0 +1,118 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+var ErrInvalidKey = errors.New("invalid object key")
+
+// Storage persists public object(s) by key. Keys are slash-separated, relative path(s), e.g. "avatars/1/full.jpg".
+// Implementations must be safe for concurrent use.
+type Storage interface {
+	// Put writes the object, replacing any existing object of the same key.
+	Put(ctx context.Context, key string, data []byte, contentType string) error
+
+	// Delete removes the object. Deleting a nonexistent object isn't an error.
+	Delete(ctx context.Context, key string) error
+
+	// URL returns the object's public URL.
+	URL(key string) string
+}
+
+// validate rejects key(s) that aren't clean, relative, slash-separated path(s) - e.g. those escaping the storage root.
+func validate(key string) error {
+	if key == "" || strings.HasPrefix(key, "/") || path.Clean(key) != key || key == ".." || strings.HasPrefix(key, "../") || strings.Contains(key, "\\") {
+		return fmt.Errorf("%w: %q", ErrInvalidKey, key)
+	}
+
+	return nil
+}
+
+// Filesystem is a [Storage] writing each object beneath the directory; object(s) are expected to be served from the base
+// URL, e.g. via [http.FileServer].
+type Filesystem struct {
+	Directory string
+	Base      string // Base represents the public URL the directory is served from, e.g. "http://localhost:8080/avatars".
+}
+
+func (f *Filesystem) Put(ctx context.Context, key string, data []byte, contentType string) error {
+	if e := validate(key); e != nil {
+		return e
+	}
+
+	name := filepath.Join(f.Directory, filepath.FromSlash(key))
+	if e := os.MkdirAll(filepath.Dir(name), 0o755); e != nil {
+		return fmt.Errorf("unable to create storage directory: %w", e)
+	}
+
+	// --> write to a temporary file and rename, so a concurrent reader never observes a partial object
+	temporary, e := os.CreateTemp(filepath.Dir(name), "."+filepath.Base(name)+".*")
+	if e != nil {
+		return fmt.Errorf("unable to create temporary object: %w", e)
+	}
+
+	defer os.Remove(temporary.Name())
+
+	if _, e := temporary.Write(data); e != nil {
+		temporary.Close()
+		return fmt.Errorf("unable to write object: %w", e)
+	}
+
+	if e := temporary.Close(); e != nil {
+		return fmt.Errorf("unable to write object: %w", e)
+	}
+
+	if e := os.Chmod(temporary.Name(), 0o644); e != nil {
+		return fmt.Errorf("unable to set object permission(s): %w", e)
+	}
+
+	if e := os.Rename(temporary.Name(), name); e != nil {
+		return fmt.Errorf("unable to commit object: %w", e)
+	}
+
+	slog.DebugContext(ctx, "Stored Object", slog.String("key", key), slog.String("path", name), slog.String("content-type", contentType))
+
+	return nil
+}
+
+func (f *Filesystem) Delete(ctx context.Context, key string) error {
+	if e := validate(key); e != nil {
+		return e
+	}
+
+	if e := os.Remove(filepath.Join(f.Directory, filepath.FromSlash(key))); e != nil && !(errors.Is(e, os.ErrNotExist)) {
+		return fmt.Errorf("unable to delete object: %w", e)
+	}
+
+	return nil
+}
+
+func (f *Filesystem) URL(key string) string {
+	return strings.TrimSuffix(f.Base, "/") + "/" + key
+}
+
+// Handler serves the directory's object(s), without directory listing(s). Callers should strip the [Filesystem.Base] URL's
+// path prefix. Object(s) are cached as immutable; keys are expected to be content-addressed.
+func (f *Filesystem) Handler() http.Handler {
+	files := http.FileServer(http.Dir(f.Directory))
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "" || strings.HasSuffix(r.URL.Path, "/") {
+			http.NotFound(w, r)
+			return
+		}
+
+		w.Header().Set("Cache-Control", "public, max-age=31536000, immutable")
+		w.Header().Set("X-Content-Type-Options", "nosniff")
+
+		files.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,39 @@
+package token
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+var (
+	ErrMissingSubject    = errors.New("token is missing a subject")
+	ErrInvalidIssuer     = jwt.ErrTokenInvalidIssuer
+	ErrInvalidAudience   = jwt.ErrTokenInvalidAudience
+	ErrInsufficientScope = errors.New("token has insufficient scope")
+	ErrInsufficientRole  = errors.New("token has insufficient role")
+	ErrMissingID         = errors.New("token is missing an identifier")
+	ErrRevoked           = errors.New("token has been revoked")
+	ErrMissingKey        = errors.New("ecdsa key has not been configured")
+	ErrInvalidKey        = errors.New("invalid ecdsa key")
+	ErrUnknownKey        = errors.New("no matching key found in key set")
+)
+
+// ClaimError is returned from [Verify] when a structurally valid token fails one of the [Options] requirement(s).
+// Callers should compare against the package's sentinel error(s) via [errors.Is].
+type ClaimError struct {
+	Claim    string      // Claim is the jwt claim key that failed validation (e.g. "iss", "aud", "scopes").
+	Expected interface{} // Expected represents the value(s) required by the verifier's [Options].
+	Actual   interface{} // Actual represents the token's value for the claim.
+
+	Err error // Err is the underlying sentinel error.
+}
+
+func (e *ClaimError) Error() string {
+	return fmt.Sprintf("%s (claim: %q, expected: %v, actual: %v)", e.Err.Error(), e.Claim, e.Expected, e.Actual)
+}
+
+func (e *ClaimError) Unwrap() error {
+	return e.Err
+}
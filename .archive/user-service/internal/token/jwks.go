@@ -0,0 +1,139 @@
+package token
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+)
+
+// JWK represents an RFC 7517 elliptic-curve JSON Web Key.
+type JWK struct {
+	Kty string `json:"kty"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+	Kid string `json:"kid,omitempty"`
+	Use string `json:"use,omitempty"`
+	Alg string `json:"alg,omitempty"`
+}
+
+// JWKS represents an RFC 7517 JSON Web Key Set.
+type JWKS struct {
+	Keys []JWK `json:"keys"`
+}
+
+// curves maps the JWK "crv" names to their [elliptic.Curve] and jwt signing algorithm.
+var curves = map[string]struct {
+	curve     elliptic.Curve
+	algorithm string
+}{
+	"P-256": {elliptic.P256(), "ES256"},
+	"P-384": {elliptic.P384(), "ES384"},
+	"P-521": {elliptic.P521(), "ES512"},
+}
+
+// NewJWK converts the public key into a [JWK], using its [Thumbprint] as the "kid".
+func NewJWK(public *ecdsa.PublicKey) JWK {
+	name := public.Curve.Params().Name
+	size := (public.Curve.Params().BitSize + 7) / 8
+
+	return JWK{
+		Kty: "EC",
+		Crv: name,
+		X:   base64.RawURLEncoding.EncodeToString(public.X.FillBytes(make([]byte, size))),
+		Y:   base64.RawURLEncoding.EncodeToString(public.Y.FillBytes(make([]byte, size))),
+		Kid: Thumbprint(public),
+		Use: "sig",
+		Alg: curves[name].algorithm,
+	}
+}
+
+// Public converts the [JWK] into an [ecdsa.PublicKey].
+func (k JWK) Public() (*ecdsa.PublicKey, error) {
+	definition, ok := curves[k.Crv]
+	if k.Kty != "EC" || !(ok) {
+		return nil, fmt.Errorf("%w: unsupported key type %q (curve: %q)", ErrInvalidKey, k.Kty, k.Crv)
+	}
+
+	x, e := base64.RawURLEncoding.DecodeString(k.X)
+	if e != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidKey, e)
+	}
+
+	y, e := base64.RawURLEncoding.DecodeString(k.Y)
+	if e != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidKey, e)
+	}
+
+	public := &ecdsa.PublicKey{Curve: definition.curve, X: new(big.Int).SetBytes(x), Y: new(big.Int).SetBytes(y)}
+	if !(public.Curve.IsOnCurve(public.X, public.Y)) {
+		return nil, fmt.Errorf("%w: point is not on curve %s", ErrInvalidKey, k.Crv)
+	}
+
+	return public, nil
+}
+
+// Key returns the public key matching kid. If kid is empty and the set contains a single key, that key is returned.
+func (s *JWKS) Key(kid string) (*ecdsa.PublicKey, error) {
+	if kid == "" && len(s.Keys) == 1 {
+		return s.Keys[0].Public()
+	}
+
+	for _, k := range s.Keys {
+		if k.Kid == kid {
+			return k.Public()
+		}
+	}
+
+	return nil, fmt.Errorf("%w (kid: %q)", ErrUnknownKey, kid)
+}
+
+// Thumbprint computes the RFC 7638 JWK thumbprint of the public key.
+func Thumbprint(public *ecdsa.PublicKey) string {
+	size := (public.Curve.Params().BitSize + 7) / 8
+
+	// --> members must be in lexicographic order, without whitespace
+	canonical := fmt.Sprintf(`{"crv":%q,"kty":"EC","x":%q,"y":%q}`,
+		public.Curve.Params().Name,
+		base64.RawURLEncoding.EncodeToString(public.X.FillBytes(make([]byte, size))),
+		base64.RawURLEncoding.EncodeToString(public.Y.FillBytes(make([]byte, size))),
+	)
+
+	sum := sha256.Sum256([]byte(canonical))
+
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+// Fetch retrieves a [JWKS] from the url (e.g. "https://example.com/.well-known/jwks.json").
+func Fetch(ctx context.Context, url string) (*JWKS, error) {
+	request, e := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if e != nil {
+		return nil, e
+	}
+
+	request.Header.Set("Accept", "application/json")
+
+	response, e := http.DefaultClient.Do(request)
+	if e != nil {
+		return nil, e
+	}
+
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected jwks response status: %s", response.Status)
+	}
+
+	var keyset JWKS
+	if e := json.NewDecoder(response.Body).Decode(&keyset); e != nil {
+		return nil, e
+	}
+
+	return &keyset, nil
+}
@@ -0,0 +1,108 @@
+package token
+
+import (
+	"crypto/ecdsa"
+	"time"
+)
+
+// Options is the configuration structure optionally mutated via the [Variadic] constructor used throughout the package.
+type Options struct {
+	TTL    time.Duration // TTL represents the lifetime of a newly created token. Defaults to one hour.
+	Leeway time.Duration // Leeway is the clock-skew tolerance applied to time-based claims during verification. Defaults to 30 seconds.
+
+	Issuer   string   // Issuer, if non-empty, requires a verified token's "iss" claim to match.
+	Audience []string // Audience, if non-empty, requires a verified token's "aud" claim to contain at least one of the value(s).
+	Scopes   []string // Scopes, if non-empty, requires a verified token to have been granted all listed scope(s).
+	Roles    []string // Roles, if non-empty, requires a verified token's subject to have been assigned at least one of the role(s).
+
+	Subject bool // Subject requires a verified token to include a non-empty "sub" claim. Defaults to true.
+
+	Denylist Denylist // Denylist, if non-nil, rejects verified tokens whose "jti" claim has been revoked.
+	Cutoffs  Cutoffs  // Cutoffs, if non-nil, rejects verified tokens issued to a revoked subject at or before its cutoff.
+
+	Public *ecdsa.PublicKey // Public, if non-nil, overrides the configured verification key.
+	Keyset *JWKS            // Keyset, if non-nil, selects the verification key by the token's "kid" header. Takes precedence over Public.
+}
+
+// Variadic represents a functional constructor for the [Options] type. Typical callers of Variadic won't need to perform
+// nil checks as all implementations first construct an [Options] reference using packaged default(s).
+type Variadic func(o *Options)
+
+// options represents a default constructor.
+func options() *Options {
+	return &Options{
+		TTL:     time.Hour,
+		Leeway:  30 * time.Second,
+		Subject: true,
+	}
+}
+
+// TTL sets [Options.TTL].
+func TTL(duration time.Duration) Variadic {
+	return func(o *Options) {
+		o.TTL = duration
+	}
+}
+
+// Leeway sets [Options.Leeway].
+func Leeway(duration time.Duration) Variadic {
+	return func(o *Options) {
+		o.Leeway = duration
+	}
+}
+
+// Issuer sets [Options.Issuer].
+func Issuer(issuer string) Variadic {
+	return func(o *Options) {
+		o.Issuer = issuer
+	}
+}
+
+// Audience appends to [Options.Audience].
+func Audience(audience ...string) Variadic {
+	return func(o *Options) {
+		o.Audience = append(o.Audience, audience...)
+	}
+}
+
+// Scopes appends to [Options.Scopes].
+func Scopes(scopes ...string) Variadic {
+	return func(o *Options) {
+		o.Scopes = append(o.Scopes, scopes...)
+	}
+}
+
+// Roles appends to [Options.Roles].
+func Roles(roles ...string) Variadic {
+	return func(o *Options) {
+		o.Roles = append(o.Roles, roles...)
+	}
+}
+
+// Revocation sets [Options.Denylist].
+func Revocation(denylist Denylist) Variadic {
+	return func(o *Options) {
+		o.Denylist = denylist
+	}
+}
+
+// SubjectRevocation sets [Options.Cutoffs].
+func SubjectRevocation(cutoffs Cutoffs) Variadic {
+	return func(o *Options) {
+		o.Cutoffs = cutoffs
+	}
+}
+
+// Key sets [Options.Public].
+func Key(public *ecdsa.PublicKey) Variadic {
+	return func(o *Options) {
+		o.Public = public
+	}
+}
+
+// Keyset sets [Options.Keyset].
+func Keyset(keyset *JWKS) Variadic {
+	return func(o *Options) {
+		o.Keyset = keyset
+	}
+}
@@ -0,0 +1,115 @@
+package refresh
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+)
+
+// Body represents the refresh + revoke handlers' structured request-body.
+type Body struct {
+	Refresh string `json:"refresh-token"`
+}
+
+// Register adds the "POST /token/refresh" and "POST /token/revoke" handler(s) to the mux.
+func (m *Manager) Register(mux *http.ServeMux) {
+	mux.Handle("POST /token/refresh", m.RefreshHandler())
+	mux.Handle("POST /token/revoke", m.RevokeHandler())
+}
+
+// RefreshHandler rotates the request-body's refresh token, responding with a new [Pair].
+func (m *Manager) RefreshHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		input, ok := body(w, r)
+		if !(ok) {
+			return
+		}
+
+		pair, e := m.Refresh(ctx, input.Refresh)
+		switch {
+		case errors.Is(e, ErrNotFound), errors.Is(e, ErrExpired), errors.Is(e, ErrRevoked), errors.Is(e, ErrReused):
+			respond(w, r, http.StatusUnauthorized, "Invalid Refresh Token")
+			return
+		case e != nil:
+			slog.ErrorContext(ctx, "Unable to Rotate Refresh Token", slog.String("error", e.Error()))
+			respond(w, r, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Cache-Control", "no-store")
+		w.WriteHeader(http.StatusOK)
+
+		json.NewEncoder(w).Encode(pair)
+	})
+}
+
+// RevokeHandler revokes the request-body's refresh token family. Unknown tokens are treated as already revoked. If a
+// [Options.Denylist] is configured, the request's bearer access token (if any) is revoked as well.
+func (m *Manager) RevokeHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		input, ok := body(w, r)
+		if !(ok) {
+			return
+		}
+
+		if e := m.Revoke(ctx, input.Refresh); e != nil {
+			slog.ErrorContext(ctx, "Unable to Revoke Refresh Token", slog.String("error", e.Error()))
+			respond(w, r, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+			return
+		}
+
+		if access, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer "); ok {
+			if e := m.Deny(ctx, strings.TrimSpace(access)); e != nil {
+				slog.ErrorContext(ctx, "Unable to Revoke Access Token", slog.String("error", e.Error()))
+				respond(w, r, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+				return
+			}
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
+
+// body decodes the request's [Body], writing a 400 response if invalid.
+func body(w http.ResponseWriter, r *http.Request) (*Body, bool) {
+	var input Body
+	if e := json.NewDecoder(http.MaxBytesReader(w, r.Body, 4096)).Decode(&input); e != nil || input.Refresh == "" {
+		respond(w, r, http.StatusBadRequest, "Invalid Request Body - \"refresh-token\" is Required")
+		return nil, false
+	}
+
+	return &input, true
+}
+
+// Problem represents an RFC 7807 problem-details response.
+type Problem struct {
+	Type     string `json:"type"`
+	Title    string `json:"title"`
+	Status   int    `json:"status"`
+	Detail   string `json:"detail,omitempty"`
+	Instance string `json:"instance,omitempty"`
+}
+
+// respond writes a [Problem] response of the status code. 401 responses additionally include an RFC 6750 "WWW-Authenticate"
+// challenge.
+func respond(w http.ResponseWriter, r *http.Request, status int, detail string) {
+	problem := &Problem{Type: "about:blank", Title: http.StatusText(status), Status: status, Detail: detail, Instance: r.URL.Path}
+
+	if status == http.StatusUnauthorized {
+		w.Header().Set("WWW-Authenticate", fmt.Sprintf("Bearer error=%q, error_description=%q", "invalid_token", detail))
+	}
+
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(status)
+
+	json.NewEncoder(w).Encode(problem)
+}
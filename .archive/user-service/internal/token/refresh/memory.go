@@ -0,0 +1,111 @@
+package refresh
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+)
+
+type entry struct {
+	record   *Record
+	consumed bool
+}
+
+// memory is an in-process [Store] implementation. Suitable for tests and single-replica local development.
+type memory struct {
+	mutex    sync.Mutex
+	records  map[string]*entry
+	families map[string]time.Time // families maps a revoked family to the time its revocation may be forgotten.
+}
+
+// Memory constructs an in-process [Store].
+func Memory() Store {
+	return &memory{
+		records:  make(map[string]*entry),
+		families: make(map[string]time.Time),
+	}
+}
+
+func (m *memory) Save(ctx context.Context, record *Record) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	m.sweep(time.Now())
+
+	m.records[record.Digest] = &entry{record: record}
+
+	return nil
+}
+
+func (m *memory) Consume(ctx context.Context, digest string) (*Record, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	value, ok := m.records[digest]
+	if !(ok) {
+		return nil, ErrNotFound
+	}
+
+	if time.Now().After(value.record.Expiration) {
+		delete(m.records, digest)
+
+		return nil, ErrExpired
+	}
+
+	if _, revoked := m.families[value.record.Family]; revoked {
+		return value.record, ErrRevoked
+	}
+
+	if value.consumed {
+		return value.record, ErrReused
+	}
+
+	value.consumed = true
+
+	return value.record, nil
+}
+
+func (m *memory) Revoke(ctx context.Context, family string, expiration time.Time) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if current, ok := m.families[family]; !(ok) || expiration.After(current) {
+		m.families[family] = expiration
+	}
+
+	return nil
+}
+
+func (m *memory) RevokeSubject(ctx context.Context, subject string, expiration time.Time) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	// --> every live family retains at least its latest rotation's record until expiration
+	for _, value := range m.records {
+		if !(strings.EqualFold(value.record.Claims.Subject, subject)) {
+			continue
+		}
+
+		if current, ok := m.families[value.record.Family]; !(ok) || expiration.After(current) {
+			m.families[value.record.Family] = expiration
+		}
+	}
+
+	return nil
+}
+
+// sweep removes expired record(s) and revocation(s). Callers must hold the mutex.
+func (m *memory) sweep(now time.Time) {
+	for digest, value := range m.records {
+		if now.After(value.record.Expiration) {
+			delete(m.records, digest)
+		}
+	}
+
+	for family, expiration := range m.families {
+		if now.After(expiration) {
+			delete(m.families, family)
+		}
+	}
+}
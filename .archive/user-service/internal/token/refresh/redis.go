@@ -0,0 +1,138 @@
+package refresh
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// prefix namespaces all redis key(s) written by the [Store].
+const prefix = "refresh-token"
+
+// cache is a redis-backed [Store] implementation. Records, consumption markers and family revocations are all written
+// with a TTL equal to their remaining lifetime, so redis evicts them without a sweeper. Each subject's families are
+// additionally indexed in a set, which expires alongside the subject's most recently saved record.
+type cache struct {
+	client redis.UniversalClient
+}
+
+// Redis constructs a redis-backed [Store] from an existing client.
+func Redis(client redis.UniversalClient) Store {
+	return &cache{client: client}
+}
+
+func (c *cache) Save(ctx context.Context, record *Record) error {
+	ttl := time.Until(record.Expiration)
+	if ttl <= 0 {
+		return ErrExpired
+	}
+
+	value, e := json.Marshal(record)
+	if e != nil {
+		return e
+	}
+
+	_, e = c.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Set(ctx, c.record(record.Digest), value, ttl)
+
+		// --> records share the manager's TTL, so the latest record's lifetime outlasts every earlier family in the set
+		pipe.SAdd(ctx, c.subject(record.Claims.Subject), record.Family)
+		pipe.Expire(ctx, c.subject(record.Claims.Subject), ttl)
+
+		return nil
+	})
+
+	return e
+}
+
+func (c *cache) Consume(ctx context.Context, digest string) (*Record, error) {
+	value, e := c.client.Get(ctx, c.record(digest)).Bytes()
+	if errors.Is(e, redis.Nil) {
+		return nil, ErrNotFound
+	} else if e != nil {
+		return nil, e
+	}
+
+	var record Record
+	if e := json.Unmarshal(value, &record); e != nil {
+		return nil, e
+	}
+
+	ttl := time.Until(record.Expiration)
+	if ttl <= 0 {
+		return nil, ErrExpired
+	}
+
+	revoked, e := c.client.Exists(ctx, c.family(record.Family)).Result()
+	if e != nil {
+		return nil, e
+	} else if revoked > 0 {
+		return &record, ErrRevoked
+	}
+
+	// --> SETNX guarantees only a single caller can consume the record, even across replicas
+	first, e := c.client.SetNX(ctx, c.consumed(digest), 1, ttl).Result()
+	if e != nil {
+		return nil, e
+	} else if !(first) {
+		return &record, ErrReused
+	}
+
+	return &record, nil
+}
+
+func (c *cache) Revoke(ctx context.Context, family string, expiration time.Time) error {
+	ttl := time.Until(expiration)
+	if ttl <= 0 {
+		return nil
+	}
+
+	return c.client.Set(ctx, c.family(family), 1, ttl).Err()
+}
+
+func (c *cache) RevokeSubject(ctx context.Context, subject string, expiration time.Time) error {
+	ttl := time.Until(expiration)
+	if ttl <= 0 {
+		return nil
+	}
+
+	families, e := c.client.SMembers(ctx, c.subject(subject)).Result()
+	if e != nil {
+		return e
+	}
+
+	_, e = c.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		for _, family := range families {
+			pipe.Set(ctx, c.family(family), 1, ttl)
+		}
+
+		// --> only the revoked member(s) are removed, so a family indexed concurrently remains revocable
+		if len(families) > 0 {
+			pipe.SRem(ctx, c.subject(subject), families)
+		}
+
+		return nil
+	})
+
+	return e
+}
+
+func (c *cache) record(digest string) string {
+	return prefix + ":" + digest
+}
+
+func (c *cache) consumed(digest string) string {
+	return prefix + ":consumed:" + digest
+}
+
+func (c *cache) family(family string) string {
+	return prefix + ":revoked-family:" + family
+}
+
+func (c *cache) subject(subject string) string {
+	return prefix + ":subject:" + strings.ToLower(subject)
+}
@@ -0,0 +1,267 @@
+package refresh
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"user-service/internal/token"
+	"user-service/internal/token/revocation"
+)
+
+func manager(t *testing.T) *Manager {
+	t.Helper()
+
+	private, e := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if e != nil {
+		t.Fatal(e)
+	}
+
+	token.Configure(private, nil)
+
+	return New(Memory())
+}
+
+func TestRefreshReuse(t *testing.T) {
+	m := manager(t)
+
+	ctx := context.Background()
+
+	original, e := m.Issue(ctx, &token.Claims{Subject: "user@example.com"})
+	if e != nil {
+		t.Fatalf("unexpected error: %v", e)
+	}
+
+	rotated, e := m.Refresh(ctx, original.Refresh)
+	if e != nil {
+		t.Fatalf("unexpected error: %v", e)
+	}
+
+	if rotated.Refresh == original.Refresh || rotated.Access == "" {
+		t.Fatalf("expected a rotated pair, got %+v", rotated)
+	}
+
+	verified, e := token.Verify(ctx, rotated.Access)
+	if e != nil {
+		t.Fatalf("unexpected error: %v", e)
+	}
+
+	if subject := verified.Claims.(*token.Claims).Subject; subject != "user@example.com" {
+		t.Errorf("expected subject %q, got %q", "user@example.com", subject)
+	}
+
+	// --> replaying the consumed token revokes the family
+	if _, e := m.Refresh(ctx, original.Refresh); !(errors.Is(e, ErrReused)) {
+		t.Fatalf("expected %v, got %v", ErrReused, e)
+	}
+
+	if _, e := m.Refresh(ctx, rotated.Refresh); !(errors.Is(e, ErrRevoked)) {
+		t.Errorf("expected %v, got %v", ErrRevoked, e)
+	}
+}
+
+func TestRevoke(t *testing.T) {
+	m := manager(t)
+
+	ctx := context.Background()
+
+	pair, e := m.Issue(ctx, &token.Claims{Subject: "user@example.com"})
+	if e != nil {
+		t.Fatalf("unexpected error: %v", e)
+	}
+
+	other, e := m.Issue(ctx, &token.Claims{Subject: "user@example.com"})
+	if e != nil {
+		t.Fatalf("unexpected error: %v", e)
+	}
+
+	if e := m.Revoke(ctx, pair.Refresh); e != nil {
+		t.Fatalf("unexpected error: %v", e)
+	}
+
+	if _, e := m.Refresh(ctx, pair.Refresh); !(errors.Is(e, ErrRevoked)) {
+		t.Errorf("expected %v, got %v", ErrRevoked, e)
+	}
+
+	// --> revocation is scoped to the token's family
+	if _, e := m.Refresh(ctx, other.Refresh); e != nil {
+		t.Errorf("unexpected error: %v", e)
+	}
+
+	if e := m.Revoke(ctx, "unknown"); e != nil {
+		t.Errorf("expected revoking an unknown token to succeed, got %v", e)
+	}
+}
+
+func TestRevokeSubject(t *testing.T) {
+	m := manager(t)
+
+	ctx := context.Background()
+
+	pair, e := m.Issue(ctx, &token.Claims{Subject: "user@example.com"})
+	if e != nil {
+		t.Fatalf("unexpected error: %v", e)
+	}
+
+	// --> a rotated family remains indexed by its latest record
+	rotated, e := m.Refresh(ctx, pair.Refresh)
+	if e != nil {
+		t.Fatalf("unexpected error: %v", e)
+	}
+
+	session, e := m.Issue(ctx, &token.Claims{Subject: "User@Example.com"})
+	if e != nil {
+		t.Fatalf("unexpected error: %v", e)
+	}
+
+	other, e := m.Issue(ctx, &token.Claims{Subject: "other@example.com"})
+	if e != nil {
+		t.Fatalf("unexpected error: %v", e)
+	}
+
+	if e := m.RevokeSubject(ctx, "user@example.com"); e != nil {
+		t.Fatalf("unexpected error: %v", e)
+	}
+
+	for _, opaque := range []string{rotated.Refresh, session.Refresh} {
+		if _, e := m.Refresh(ctx, opaque); !(errors.Is(e, ErrRevoked)) {
+			t.Errorf("expected %v, got %v", ErrRevoked, e)
+		}
+	}
+
+	if _, e := m.Refresh(ctx, other.Refresh); e != nil {
+		t.Errorf("unexpected error for another subject's family: %v", e)
+	}
+
+	// --> families issued after the revocation are unaffected
+	subsequent, e := m.Issue(ctx, &token.Claims{Subject: "user@example.com"})
+	if e != nil {
+		t.Fatalf("unexpected error: %v", e)
+	}
+
+	if _, e := m.Refresh(ctx, subsequent.Refresh); e != nil {
+		t.Errorf("unexpected error for a subsequent family: %v", e)
+	}
+
+	if e := m.RevokeSubject(ctx, ""); !(errors.Is(e, token.ErrMissingSubject)) {
+		t.Errorf("expected %v, got %v", token.ErrMissingSubject, e)
+	}
+}
+
+func TestHandlers(t *testing.T) {
+	m := manager(t)
+
+	mux := http.NewServeMux()
+	m.Register(mux)
+
+	pair, e := m.Issue(context.Background(), &token.Claims{Subject: "user@example.com"})
+	if e != nil {
+		t.Fatalf("unexpected error: %v", e)
+	}
+
+	post := func(path, body string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, httptest.NewRequest(http.MethodPost, path, strings.NewReader(body)))
+
+		return w
+	}
+
+	w := post("/token/refresh", `{"refresh-token": "`+pair.Refresh+`"}`)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d", http.StatusOK, w.Code)
+	}
+
+	var rotated Pair
+	if e := json.NewDecoder(w.Body).Decode(&rotated); e != nil {
+		t.Fatalf("unexpected error: %v", e)
+	}
+
+	if rotated.Type != "Bearer" || rotated.Access == "" || rotated.Refresh == "" {
+		t.Errorf("unexpected pair: %+v", rotated)
+	}
+
+	w = post("/token/refresh", `{"refresh-token": "`+pair.Refresh+`"}`)
+	if w.Code != http.StatusUnauthorized {
+		t.Errorf("expected status %d, got %d", http.StatusUnauthorized, w.Code)
+	}
+
+	if content := w.Header().Get("Content-Type"); content != "application/problem+json" {
+		t.Errorf("expected a problem response, got %q", content)
+	}
+
+	if w.Header().Get("WWW-Authenticate") == "" {
+		t.Error("expected a WWW-Authenticate challenge")
+	}
+
+	var problem Problem
+	if e := json.NewDecoder(w.Body).Decode(&problem); e != nil || problem.Status != http.StatusUnauthorized || problem.Instance != "/token/refresh" {
+		t.Errorf("unexpected problem: %+v (%v)", problem, e)
+	}
+
+	if w := post("/token/refresh", `{}`); w.Code != http.StatusBadRequest {
+		t.Errorf("expected status %d, got %d", http.StatusBadRequest, w.Code)
+	}
+
+	fresh, e := m.Issue(context.Background(), &token.Claims{Subject: "user@example.com"})
+	if e != nil {
+		t.Fatalf("unexpected error: %v", e)
+	}
+
+	if w := post("/token/revoke", `{"refresh-token": "`+fresh.Refresh+`"}`); w.Code != http.StatusNoContent {
+		t.Fatalf("expected status %d, got %d", http.StatusNoContent, w.Code)
+	}
+
+	if w := post("/token/refresh", `{"refresh-token": "`+fresh.Refresh+`"}`); w.Code != http.StatusUnauthorized {
+		t.Errorf("expected status %d, got %d", http.StatusUnauthorized, w.Code)
+	}
+
+	if w := post("/token/revoke", `not json`); w.Code != http.StatusBadRequest {
+		t.Errorf("expected status %d, got %d", http.StatusBadRequest, w.Code)
+	}
+}
+
+func TestRevokeAccess(t *testing.T) {
+	manager(t)
+
+	ctx := context.Background()
+
+	denylist := revocation.Memory()
+	m := New(Memory(), Denylist(denylist))
+
+	mux := http.NewServeMux()
+	m.Register(mux)
+
+	pair, e := m.Issue(ctx, &token.Claims{Subject: "user@example.com"})
+	if e != nil {
+		t.Fatalf("unexpected error: %v", e)
+	}
+
+	if _, e := token.Verify(ctx, pair.Access, token.Revocation(denylist)); e != nil {
+		t.Fatalf("unexpected error: %v", e)
+	}
+
+	request := httptest.NewRequest(http.MethodPost, "/token/revoke", strings.NewReader(`{"refresh-token": "`+pair.Refresh+`"}`))
+	request.Header.Set("Authorization", "Bearer "+pair.Access)
+
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, request)
+	if w.Code != http.StatusNoContent {
+		t.Fatalf("expected status %d, got %d", http.StatusNoContent, w.Code)
+	}
+
+	// --> logout takes effect immediately, rather than upon the access token's expiration
+	if _, e := token.Verify(ctx, pair.Access, token.Revocation(denylist)); !(errors.Is(e, token.ErrRevoked)) {
+		t.Errorf("expected %v, got %v", token.ErrRevoked, e)
+	}
+
+	if e := m.Deny(ctx, "not.a.jwt"); e != nil {
+		t.Errorf("expected an unverifiable access token to be ignored, got %v", e)
+	}
+}
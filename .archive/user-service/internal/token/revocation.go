@@ -0,0 +1,64 @@
+package token
+
+import (
+	"context"
+	"time"
+)
+
+// Denylist is the pluggable store of revoked token identifiers ("jti" claims). See [Revocation] for enabling the check during [Verify].
+type Denylist interface {
+	// Revoke adds the identifier to the denylist. Implementations may forget the identifier after expiration, as the token
+	// itself will no longer verify.
+	Revoke(ctx context.Context, id string, expiration time.Time) error
+
+	// Revoked reports whether the identifier has been revoked.
+	Revoked(ctx context.Context, id string) (bool, error)
+}
+
+// Revoke adds the claims' identifier to the denylist for the remainder of the token's lifetime. Claims without an expiration
+// are retained for [Options.TTL]; the settings should match those the token was created and is verified with.
+func Revoke(ctx context.Context, denylist Denylist, claims *Claims, settings ...Variadic) error {
+	o := options()
+	for _, option := range settings {
+		option(o)
+	}
+
+	if claims.ID == "" {
+		return ErrMissingID
+	}
+
+	expiration := claims.Expiration()
+	if expiration.IsZero() {
+		expiration = time.Now().Add(o.TTL)
+	}
+
+	// --> account for verifiers configured with clock-skew leeway
+	return denylist.Revoke(ctx, claims.ID, expiration.Add(o.Leeway))
+}
+
+// Cutoffs is the pluggable store of subject-wide revocation(s). Unlike a [Denylist], revoking a subject doesn't require the
+// identifier of each outstanding token: every token issued to the subject at or before the cutoff is rejected. See
+// [SubjectRevocation] for enabling the check during [Verify].
+type Cutoffs interface {
+	// Cut rejects all token(s) issued to the subject at or before the instant. Implementations may forget the cutoff after
+	// the ttl, as every affected token will have expired.
+	Cut(ctx context.Context, subject string, instant time.Time, ttl time.Duration) error
+
+	// Cutoff returns the subject's revocation instant, or the zero-value if the subject was never revoked.
+	Cutoff(ctx context.Context, subject string) (time.Time, error)
+}
+
+// RevokeSubject revokes every token issued to the subject up to now. The cutoff is retained for [Options.TTL] + [Options.Leeway];
+// callers issuing longer-lived token(s) should provide a matching [TTL] setting.
+func RevokeSubject(ctx context.Context, cutoffs Cutoffs, subject string, settings ...Variadic) error {
+	o := options()
+	for _, option := range settings {
+		option(o)
+	}
+
+	if subject == "" {
+		return ErrMissingSubject
+	}
+
+	return cutoffs.Cut(ctx, subject, time.Now(), o.TTL+o.Leeway)
+}
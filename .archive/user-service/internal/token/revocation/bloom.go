@@ -0,0 +1,223 @@
+package revocation
+
+import (
+	"context"
+	"errors"
+	"hash/fnv"
+	"log/slog"
+	"math"
+	"sync"
+	"time"
+
+	"user-service/internal/token"
+)
+
+// Lister is implemented by [token.Denylist] stores capable of enumerating all currently revoked identifier(s).
+type Lister interface {
+	List(ctx context.Context) ([]string, error)
+}
+
+// Watcher is implemented by [token.Denylist] stores capable of streaming revocations made by other process(es).
+type Watcher interface {
+	Watch(ctx context.Context, callback func(id string)) error
+}
+
+// Options is the configuration structure optionally mutated via the [Variadic] constructor used throughout the package.
+type Options struct {
+	Capacity uint          // Capacity is the expected number of concurrently revoked identifier(s). Defaults to 100,000.
+	Rate     float64       // Rate is the bloom filter's target false-positive rate. Defaults to 0.01.
+	Rebuild  time.Duration // Rebuild is the interval the filter is re-hydrated at to shed expired identifier(s). Defaults to 5 minutes.
+}
+
+// Variadic represents a functional constructor for the [Options] type. Typical callers of Variadic won't need to perform
+// nil checks as all implementations first construct an [Options] reference using packaged default(s).
+type Variadic func(o *Options)
+
+// options represents a default constructor.
+func options() *Options {
+	return &Options{
+		Capacity: 100_000,
+		Rate:     0.01,
+		Rebuild:  5 * time.Minute,
+	}
+}
+
+var (
+	ErrUnsupportedStore = errors.New("denylist store must implement revocation.Lister")
+	ErrInvalidCapacity  = errors.New("bloom filter capacity must be greater than zero")
+	ErrInvalidRate      = errors.New("bloom filter false-positive rate must be between zero and one (exclusive)")
+)
+
+// bloom is a [token.Denylist] wrapper that consults a local bloom filter before the underlying store. Because bloom filters
+// never produce false negatives, identifiers absent from the filter skip the store round-trip entirely.
+type bloom struct {
+	store   token.Denylist
+	options *Options
+
+	mutex  sync.RWMutex
+	filter *filter
+	next   *filter // next is non-nil while a rebuild is in progress; concurrent revocations are added to both filters.
+}
+
+// Bloom wraps a [token.Denylist] with a local bloom filter cache. The store must implement [Lister] for hydration; if it
+// also implements [Watcher], revocations made by other replicas are added to the filter as they happen. Otherwise, they
+// only become visible after the next rebuild. Rebuilding stops once ctx is cancelled. [Options.Capacity] must be non-zero,
+// and [Options.Rate] must fall within (0, 1).
+func Bloom(ctx context.Context, store token.Denylist, settings ...Variadic) (token.Denylist, error) {
+	o := options()
+	for _, option := range settings {
+		option(o)
+	}
+
+	if _, ok := store.(Lister); !(ok) {
+		return nil, ErrUnsupportedStore
+	}
+
+	if o.Capacity == 0 {
+		return nil, ErrInvalidCapacity
+	}
+
+	// --> a non-positive log (rate >= 1) or an infinite one (rate <= 0) would otherwise size a degenerate filter
+	if !(o.Rate > 0 && o.Rate < 1) {
+		return nil, ErrInvalidRate
+	}
+
+	b := &bloom{store: store, options: o, filter: size(o.Capacity, o.Rate)}
+
+	if watcher, ok := store.(Watcher); ok {
+		if e := watcher.Watch(ctx, b.add); e != nil {
+			return nil, e
+		}
+	}
+
+	if e := b.rebuild(ctx); e != nil {
+		return nil, e
+	}
+
+	if o.Rebuild > 0 {
+		go func() {
+			ticker := time.NewTicker(o.Rebuild)
+			defer ticker.Stop()
+
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case <-ticker.C:
+					if e := b.rebuild(ctx); e != nil {
+						slog.WarnContext(ctx, "Unable to Rebuild Token Revocation Bloom Filter", slog.String("error", e.Error()))
+					}
+				}
+			}
+		}()
+	}
+
+	return b, nil
+}
+
+func (b *bloom) Revoke(ctx context.Context, id string, expiration time.Time) error {
+	if e := b.store.Revoke(ctx, id, expiration); e != nil {
+		return e
+	}
+
+	b.add(id)
+
+	return nil
+}
+
+func (b *bloom) Revoked(ctx context.Context, id string) (bool, error) {
+	b.mutex.RLock()
+	candidate := b.filter.test(id)
+	b.mutex.RUnlock()
+
+	if !(candidate) {
+		return false, nil
+	}
+
+	return b.store.Revoked(ctx, id)
+}
+
+func (b *bloom) add(id string) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	b.filter.add(id)
+	if b.next != nil {
+		b.next.add(id)
+	}
+}
+
+// rebuild re-hydrates a new filter from the store, swapping it in upon completion.
+func (b *bloom) rebuild(ctx context.Context) error {
+	b.mutex.Lock()
+	if b.next != nil {
+		b.mutex.Unlock()
+		return nil
+	}
+
+	next := size(b.options.Capacity, b.options.Rate)
+	b.next = next
+	b.mutex.Unlock()
+
+	identifiers, e := b.store.(Lister).List(ctx)
+
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	if e != nil {
+		b.next = nil
+		return e
+	}
+
+	for _, identifier := range identifiers {
+		next.add(identifier)
+	}
+
+	b.filter, b.next = next, nil
+
+	return nil
+}
+
+// filter is a fixed-size bloom filter using double hashing over a single 64-bit FNV-1a digest.
+type filter struct {
+	bits   []uint64
+	size   uint64
+	hashes uint64
+}
+
+// size constructs a [filter] with the optimal bit-count and hash-count for the given capacity and false-positive rate.
+func size(capacity uint, rate float64) *filter {
+	n := math.Max(float64(capacity), 1)
+	m := math.Ceil(-n * math.Log(rate) / (math.Ln2 * math.Ln2))
+	k := math.Max(math.Round(m/n*math.Ln2), 1)
+
+	return &filter{bits: make([]uint64, (uint64(m)+63)/64), size: uint64(m), hashes: uint64(k)}
+}
+
+func (f *filter) locations(value string) (uint64, uint64) {
+	h := fnv.New64a()
+	h.Write([]byte(value))
+	sum := h.Sum64()
+
+	return sum & 0xffffffff, (sum >> 32) | 1
+}
+
+func (f *filter) add(value string) {
+	a, b := f.locations(value)
+	for i := uint64(0); i < f.hashes; i++ {
+		position := (a + i*b) % f.size
+		f.bits[position/64] |= 1 << (position % 64)
+	}
+}
+
+func (f *filter) test(value string) bool {
+	a, b := f.locations(value)
+	for i := uint64(0); i < f.hashes; i++ {
+		position := (a + i*b) % f.size
+		if f.bits[position/64]&(1<<(position%64)) == 0 {
+			return false
+		}
+	}
+
+	return true
+}
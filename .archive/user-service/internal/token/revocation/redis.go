@@ -0,0 +1,93 @@
+package revocation
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"user-service/internal/token"
+)
+
+// prefix namespaces all redis key(s) written by the [token.Denylist].
+const prefix = "revoked-token"
+
+// channel is the redis pub/sub channel revoked identifier(s) are published to.
+const channel = "revoked-token"
+
+// cache is a redis-backed [token.Denylist] implementation. Each identifier is written with a TTL equal to the token's
+// remaining lifetime, and is additionally published so [Bloom] caches on other replicas learn about it immediately.
+type cache struct {
+	client redis.UniversalClient
+}
+
+// Redis constructs a redis-backed [token.Denylist] from an existing client.
+func Redis(client redis.UniversalClient) token.Denylist {
+	return &cache{client: client}
+}
+
+func (c *cache) Revoke(ctx context.Context, id string, expiration time.Time) error {
+	ttl := time.Until(expiration)
+	if ttl <= 0 {
+		return nil
+	}
+
+	if e := c.client.Set(ctx, prefix+":"+id, 1, ttl).Err(); e != nil {
+		return e
+	}
+
+	return c.client.Publish(ctx, channel, id).Err()
+}
+
+func (c *cache) Revoked(ctx context.Context, id string) (bool, error) {
+	count, e := c.client.Exists(ctx, prefix+":"+id).Result()
+	if e != nil {
+		return false, e
+	}
+
+	return count > 0, nil
+}
+
+// List implements [Lister].
+func (c *cache) List(ctx context.Context) ([]string, error) {
+	var identifiers []string
+
+	iterator := c.client.Scan(ctx, 0, prefix+":*", 1000).Iterator()
+	for iterator.Next(ctx) {
+		identifiers = append(identifiers, strings.TrimPrefix(iterator.Val(), prefix+":"))
+	}
+
+	return identifiers, iterator.Err()
+}
+
+// Watch implements [Watcher].
+func (c *cache) Watch(ctx context.Context, callback func(id string)) error {
+	subscription := c.client.Subscribe(ctx, channel)
+
+	// --> ensure the subscription is established before returning
+	if _, e := subscription.Receive(ctx); e != nil {
+		subscription.Close()
+		return e
+	}
+
+	go func() {
+		defer subscription.Close()
+
+		messages := subscription.Channel()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case message, ok := <-messages:
+				if !(ok) {
+					return
+				}
+
+				callback(message.Payload)
+			}
+		}
+	}()
+
+	return nil
+}
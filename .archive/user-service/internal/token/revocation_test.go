@@ -0,0 +1,332 @@
+package token_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"errors"
+	"os"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/redis/go-redis/v9"
+
+	"user-service/internal/token"
+	"user-service/internal/token/revocation"
+)
+
+func signer(t *testing.T) {
+	t.Helper()
+
+	private, e := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if e != nil {
+		t.Fatal(e)
+	}
+
+	token.Configure(private, nil)
+}
+
+func TestVerifyRevoked(t *testing.T) {
+	signer(t)
+
+	ctx := context.Background()
+
+	denylist := revocation.Memory()
+
+	claims := &token.Claims{Subject: "user@example.com", ID: "revoked"}
+
+	signed, e := token.Create(ctx, claims)
+	if e != nil {
+		t.Fatalf("unexpected error: %v", e)
+	}
+
+	other, e := token.Create(ctx, &token.Claims{Subject: "user@example.com"})
+	if e != nil {
+		t.Fatalf("unexpected error: %v", e)
+	}
+
+	if _, e := token.Verify(ctx, signed, token.Revocation(denylist)); e != nil {
+		t.Fatalf("unexpected error: %v", e)
+	}
+
+	if e := token.Revoke(ctx, denylist, claims); e != nil {
+		t.Fatalf("unexpected error: %v", e)
+	}
+
+	if _, e := token.Verify(ctx, signed, token.Revocation(denylist)); !(errors.Is(e, token.ErrRevoked)) {
+		t.Errorf("expected %v, got %v", token.ErrRevoked, e)
+	}
+
+	if _, e := token.Verify(ctx, other, token.Revocation(denylist)); e != nil {
+		t.Errorf("unexpected error: %v", e)
+	}
+
+	if e := token.Revoke(ctx, denylist, &token.Claims{Subject: "user@example.com"}); !(errors.Is(e, token.ErrMissingID)) {
+		t.Errorf("expected %v, got %v", token.ErrMissingID, e)
+	}
+}
+
+func TestSubjectRevocation(t *testing.T) {
+	signer(t)
+
+	ctx := context.Background()
+
+	cutoffs := revocation.MemoryCutoffs()
+
+	issued := time.Now().Add(-time.Minute)
+	previous, e := token.Create(ctx, &token.Claims{Subject: "user@example.com", IssuedAt: jwt.NewNumericDate(issued), NotBefore: jwt.NewNumericDate(issued)})
+	if e != nil {
+		t.Fatalf("unexpected error: %v", e)
+	}
+
+	other, e := token.Create(ctx, &token.Claims{Subject: "other@example.com"})
+	if e != nil {
+		t.Fatalf("unexpected error: %v", e)
+	}
+
+	if e := token.RevokeSubject(ctx, cutoffs, "User@Example.com"); e != nil {
+		t.Fatalf("unexpected error: %v", e)
+	}
+
+	if _, e := token.Verify(ctx, previous, token.SubjectRevocation(cutoffs)); !(errors.Is(e, token.ErrRevoked)) {
+		t.Errorf("expected %v for a token issued before the cutoff, got %v", token.ErrRevoked, e)
+	}
+
+	if _, e := token.Verify(ctx, other, token.SubjectRevocation(cutoffs)); e != nil {
+		t.Errorf("unexpected error for another subject's token: %v", e)
+	}
+
+	issued = time.Now().Add(time.Second)
+	subsequent, e := token.Create(ctx, &token.Claims{Subject: "user@example.com", IssuedAt: jwt.NewNumericDate(issued), NotBefore: jwt.NewNumericDate(time.Now())})
+	if e != nil {
+		t.Fatalf("unexpected error: %v", e)
+	}
+
+	if _, e := token.Verify(ctx, subsequent, token.SubjectRevocation(cutoffs)); e != nil {
+		t.Errorf("unexpected error for a token issued after the cutoff: %v", e)
+	}
+}
+
+func TestRevokeSubjectSettings(t *testing.T) {
+	ctx := context.Background()
+
+	cutoffs := revocation.MemoryCutoffs()
+
+	// --> the cutoff is retained for the configured token lifetime, plus leeway
+	if e := token.RevokeSubject(ctx, cutoffs, "short@example.com", token.TTL(-time.Minute), token.Leeway(0)); e != nil {
+		t.Fatalf("unexpected error: %v", e)
+	}
+
+	if cutoff, e := cutoffs.Cutoff(ctx, "short@example.com"); e != nil || !(cutoff.IsZero()) {
+		t.Errorf("expected an elapsed cutoff to be forgotten, got %s (%v)", cutoff, e)
+	}
+
+	if e := token.RevokeSubject(ctx, cutoffs, "long@example.com", token.TTL(24*time.Hour)); e != nil {
+		t.Fatalf("unexpected error: %v", e)
+	}
+
+	if cutoff, e := cutoffs.Cutoff(ctx, "long@example.com"); e != nil || cutoff.IsZero() {
+		t.Errorf("expected a retained cutoff, got %s (%v)", cutoff, e)
+	}
+
+	if e := token.RevokeSubject(ctx, cutoffs, ""); !(errors.Is(e, token.ErrMissingSubject)) {
+		t.Errorf("expected %v, got %v", token.ErrMissingSubject, e)
+	}
+}
+
+func TestRevokeSettings(t *testing.T) {
+	ctx := context.Background()
+
+	denylist := revocation.Memory()
+
+	// --> without an expiration claim, the identifier is retained for the configured lifetime
+	if e := token.Revoke(ctx, denylist, &token.Claims{ID: "short"}, token.TTL(-time.Minute), token.Leeway(0)); e != nil {
+		t.Fatalf("unexpected error: %v", e)
+	}
+
+	if revoked, e := denylist.Revoked(ctx, "short"); e != nil || revoked {
+		t.Errorf("expected an elapsed revocation to be forgotten, got %t (%v)", revoked, e)
+	}
+
+	if e := token.Revoke(ctx, denylist, &token.Claims{ID: "long"}, token.TTL(time.Hour)); e != nil {
+		t.Fatalf("unexpected error: %v", e)
+	}
+
+	if revoked, e := denylist.Revoked(ctx, "long"); e != nil || !(revoked) {
+		t.Errorf("expected identifier to be revoked, got %t (%v)", revoked, e)
+	}
+}
+
+func TestMemoryDenylist(t *testing.T) {
+	ctx := context.Background()
+
+	denylist := revocation.Memory()
+
+	if e := denylist.Revoke(ctx, "active", time.Now().Add(time.Minute)); e != nil {
+		t.Fatalf("unexpected error: %v", e)
+	}
+
+	if e := denylist.Revoke(ctx, "expired", time.Now().Add(-time.Minute)); e != nil {
+		t.Fatalf("unexpected error: %v", e)
+	}
+
+	tests := map[string]bool{"active": true, "expired": false, "unknown": false}
+	for id, expected := range tests {
+		revoked, e := denylist.Revoked(ctx, id)
+		if e != nil {
+			t.Fatalf("unexpected error: %v", e)
+		}
+
+		if revoked != expected {
+			t.Errorf("expected %q revoked = %t, got %t", id, expected, revoked)
+		}
+	}
+
+	identifiers, e := denylist.(revocation.Lister).List(ctx)
+	if e != nil {
+		t.Fatalf("unexpected error: %v", e)
+	}
+
+	if !(slices.Equal(identifiers, []string{"active"})) {
+		t.Errorf("expected [active], got %v", identifiers)
+	}
+}
+
+// TestRedisDenylist requires a disposable redis instance, specified by the "REDIS_TEST_ADDRESS" environment variable.
+func TestRedisDenylist(t *testing.T) {
+	address := os.Getenv("REDIS_TEST_ADDRESS")
+	if address == "" {
+		t.Skip("REDIS_TEST_ADDRESS not set")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	client := redis.NewClient(&redis.Options{Addr: address})
+	defer client.Close()
+
+	denylist := revocation.Redis(client)
+
+	var received = make(chan string, 1)
+	if e := denylist.(revocation.Watcher).Watch(ctx, func(id string) { received <- id }); e != nil {
+		t.Fatalf("unexpected error: %v", e)
+	}
+
+	id := "redis-" + time.Now().Format("150405.000000000")
+	if e := denylist.Revoke(ctx, id, time.Now().Add(time.Minute)); e != nil {
+		t.Fatalf("unexpected error: %v", e)
+	}
+
+	t.Cleanup(func() { client.Del(context.Background(), "revoked-token:"+id) })
+
+	if revoked, e := denylist.Revoked(ctx, id); e != nil || !(revoked) {
+		t.Errorf("expected identifier to be revoked, got %t (%v)", revoked, e)
+	}
+
+	identifiers, e := denylist.(revocation.Lister).List(ctx)
+	if e != nil {
+		t.Fatalf("unexpected error: %v", e)
+	}
+
+	if !(slices.Contains(identifiers, id)) {
+		t.Errorf("expected %v to contain %q", identifiers, id)
+	}
+
+	select {
+	case value := <-received:
+		if value != id {
+			t.Errorf("expected published identifier %q, got %q", id, value)
+		}
+	case <-time.After(5 * time.Second):
+		t.Error("expected the revocation to be published")
+	}
+}
+
+func TestBloomOptions(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name     string
+		setting  revocation.Variadic
+		expected error
+	}{
+		{"Capacity", func(o *revocation.Options) { o.Capacity = 0 }, revocation.ErrInvalidCapacity},
+		{"Zero-Rate", func(o *revocation.Options) { o.Rate = 0 }, revocation.ErrInvalidRate},
+		{"Unit-Rate", func(o *revocation.Options) { o.Rate = 1 }, revocation.ErrInvalidRate},
+		{"Negative-Rate", func(o *revocation.Options) { o.Rate = -0.5 }, revocation.ErrInvalidRate},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if _, e := revocation.Bloom(ctx, revocation.Memory(), test.setting); !(errors.Is(e, test.expected)) {
+				t.Errorf("expected %v, got %v", test.expected, e)
+			}
+		})
+	}
+}
+
+func TestBloomRebuild(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	store := revocation.Memory()
+
+	if e := store.Revoke(ctx, "hydrated", time.Now().Add(time.Minute)); e != nil {
+		t.Fatalf("unexpected error: %v", e)
+	}
+
+	denylist, e := revocation.Bloom(ctx, store, func(o *revocation.Options) { o.Capacity = 100; o.Rebuild = 10 * time.Millisecond })
+	if e != nil {
+		t.Fatalf("unexpected error: %v", e)
+	}
+
+	if revoked, e := denylist.Revoked(ctx, "hydrated"); e != nil || !(revoked) {
+		t.Errorf("expected hydrated identifier to be revoked, got %t (%v)", revoked, e)
+	}
+
+	if e := denylist.Revoke(ctx, "local", time.Now().Add(time.Minute)); e != nil {
+		t.Fatalf("unexpected error: %v", e)
+	}
+
+	if revoked, e := denylist.Revoked(ctx, "local"); e != nil || !(revoked) {
+		t.Errorf("expected local identifier to be revoked, got %t (%v)", revoked, e)
+	}
+
+	// --> a revocation written to the store directly (e.g. by another replica, without a watcher) is absent from the
+	// filter until the next rebuild swaps in a freshly hydrated one
+	if e := store.Revoke(ctx, "remote", time.Now().Add(time.Minute)); e != nil {
+		t.Fatalf("unexpected error: %v", e)
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		revoked, e := denylist.Revoked(ctx, "remote")
+		if e != nil {
+			t.Fatalf("unexpected error: %v", e)
+		}
+
+		if revoked {
+			break
+		}
+
+		if time.Now().After(deadline) {
+			t.Fatal("expected the rebuilt filter to include the remote identifier")
+		}
+
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	// --> the rotated filter must retain identifier(s) revoked prior to the rebuild
+	for _, id := range []string{"hydrated", "local"} {
+		if revoked, e := denylist.Revoked(ctx, id); e != nil || !(revoked) {
+			t.Errorf("expected %q to remain revoked after rebuild, got %t (%v)", id, revoked, e)
+		}
+	}
+
+	if revoked, e := denylist.Revoked(ctx, "unknown"); e != nil || revoked {
+		t.Errorf("expected unknown identifier to not be revoked, got %t (%v)", revoked, e)
+	}
+}
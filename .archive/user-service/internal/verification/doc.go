@@ -0,0 +1,6 @@
+// Package verification implements the email verification workflow for the User's [users.UserVerificationStatus]:
+//
+//   - Upon registration, [Verifier.Issue] mints a signed, single-use, expiring verification token and its link.
+//   - [Verifier.Redeem] consumes the token, transitioning the PENDING user to VERIFIED.
+//   - [Verifier.Sweep] periodically transitions PENDING user(s) whose token(s) expired to TIMEOUT.
+package verification
@@ -0,0 +1,48 @@
+package verification
+
+import (
+	"time"
+)
+
+// Options is the configuration structure optionally mutated via the [Variadic] constructor used throughout the package.
+type Options struct {
+	TTL time.Duration // TTL represents the lifetime of a verification token. Defaults to 24 hours.
+	URL string        // URL represents the verification endpoint, to which the "token" query parameter is appended. Defaults to "http://localhost:8080/verify".
+
+	Interval time.Duration // Interval represents the duration between [Verifier.Sweep] pass(es). Defaults to 5 minutes.
+}
+
+// Variadic represents a functional constructor for the [Options] type. Typical callers of Variadic won't need to perform
+// nil checks as all implementations first construct an [Options] reference using packaged default(s).
+type Variadic func(o *Options)
+
+// options represents a default constructor.
+func options() *Options {
+	return &Options{
+		TTL: 24 * time.Hour,
+		URL: "http://localhost:8080/verify",
+
+		Interval: 5 * time.Minute,
+	}
+}
+
+// TTL sets [Options.TTL].
+func TTL(duration time.Duration) Variadic {
+	return func(o *Options) {
+		o.TTL = duration
+	}
+}
+
+// URL sets [Options.URL].
+func URL(url string) Variadic {
+	return func(o *Options) {
+		o.URL = url
+	}
+}
+
+// Interval sets [Options.Interval].
+func Interval(duration time.Duration) Variadic {
+	return func(o *Options) {
+		o.Interval = duration
+	}
+}
@@ -0,0 +1,167 @@
+package verification
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"user-service/internal/database"
+	"user-service/internal/mail"
+	"user-service/internal/token"
+	"user-service/models/users"
+)
+
+const (
+	Audience = "user-service/verification" // Audience is the "aud" claim distinguishing verification token(s) from access token(s).
+	Scope    = "verification"              // Scope is the sole scope granted to a verification token.
+)
+
+// lock is the transaction-scoped advisory lock key held during a [Verifier.Sweep] pass, so only one replica sweeps at a time.
+const lock int64 = 0x7573657276657269 // --> "userveri"
+
+var (
+	ErrInvalidToken = errors.New("invalid verification token")
+	ErrUnavailable  = errors.New("verification token already used or expired")
+	ErrNotPending   = errors.New("user is not pending verification")
+)
+
+// Verifier issues and redeems verification token(s).
+type Verifier struct {
+	options *Options
+}
+
+// New constructs a [Verifier].
+func New(settings ...Variadic) *Verifier {
+	o := options()
+	for _, option := range settings {
+		option(o)
+	}
+
+	return &Verifier{options: o}
+}
+
+// Issue mints a verification token for the user, recording it within the transaction, and returns the verification link.
+func (v *Verifier) Issue(ctx context.Context, tx users.DBTX, user *users.User) (string, error) {
+	identifier := make([]byte, 16)
+	if _, e := rand.Read(identifier); e != nil {
+		return "", e
+	}
+
+	// --> numeric date(s) have second precision; truncate so the token and its record expire together
+	expiration := time.Now().Add(v.options.TTL).Truncate(time.Second)
+
+	claims := &token.Claims{Subject: user.Email, Audience: jwt.ClaimStrings{Audience}, Scopes: []string{Scope}, ExpiresAt: jwt.NewNumericDate(expiration), ID: hex.EncodeToString(identifier)}
+
+	signed, e := token.Create(ctx, claims)
+	if e != nil {
+		return "", fmt.Errorf("unable to sign verification token: %w", e)
+	}
+
+	arguments := &users.CreateVerificationParams{ID: claims.ID, User: user.ID, Expiration: pgtype.Timestamptz{Time: expiration, Valid: true}}
+	if e := users.New().CreateVerification(ctx, tx, arguments); e != nil {
+		return "", fmt.Errorf("unable to record verification token: %w", e)
+	}
+
+	link, e := url.Parse(v.options.URL)
+	if e != nil {
+		return "", fmt.Errorf("invalid verification url: %w", e)
+	}
+
+	query := link.Query()
+	query.Set("token", signed)
+	link.RawQuery = query.Encode()
+
+	return link.String(), nil
+}
+
+// Message composes the verification email containing the link.
+func (v *Verifier) Message(email, link string) *mail.Message {
+	body := fmt.Sprintf("Welcome!\n\nPlease verify your email address by visiting the following link within %s:\n\n%s\n\nIf you didn't create an account, you may ignore this email.\n", v.options.TTL, link)
+
+	return &mail.Message{To: email, Subject: "Verify Your Email Address", Body: body}
+}
+
+// Redeem verifies the token's signature, consumes it, and transitions its PENDING user to VERIFIED - returning the user's ID.
+// Tokens are single-use; as consumption is recorded within tx, a failed transaction leaves the token redeemable.
+func (v *Verifier) Redeem(ctx context.Context, tx users.DBTX, raw string) (int64, error) {
+	verified, e := token.Verify(ctx, raw, token.Audience(Audience), token.Scopes(Scope))
+	if e != nil {
+		return 0, errors.Join(ErrInvalidToken, e)
+	}
+
+	claims := verified.Claims.(*token.Claims)
+
+	user, e := users.New().ConsumeVerification(ctx, tx, claims.ID)
+	switch {
+	case errors.Is(e, pgx.ErrNoRows):
+		return 0, ErrUnavailable
+	case e != nil:
+		return 0, fmt.Errorf("unable to consume verification token: %w", e)
+	}
+
+	count, e := users.New().Verify(ctx, tx, user)
+	switch {
+	case e != nil:
+		return 0, fmt.Errorf("unable to verify user: %w", e)
+	case count == 0:
+		return 0, ErrNotPending
+	}
+
+	return user, nil
+}
+
+// Sweep transitions PENDING user(s) without an unexpired verification token to TIMEOUT every [Options.Interval], until
+// the context is canceled. Concurrent sweepers - e.g. one per replica - coordinate via an advisory lock.
+func (v *Verifier) Sweep(ctx context.Context, pool *pgxpool.Pool) {
+	ticker := time.NewTicker(v.options.Interval)
+	defer ticker.Stop()
+
+	for {
+		if e := v.sweep(ctx, pool); e != nil && ctx.Err() == nil {
+			slog.ErrorContext(ctx, "Unable to Sweep Expired Verification(s)", slog.String("error", e.Error()))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// sweep performs a single [Verifier.Sweep] pass.
+func (v *Verifier) sweep(ctx context.Context, pool *pgxpool.Pool) error {
+	return database.WithTx(ctx, pool, nil, func(tx users.DBTX) error {
+		var acquired bool
+		if e := tx.QueryRow(ctx, "SELECT pg_try_advisory_xact_lock($1)", lock).Scan(&acquired); e != nil {
+			return e
+		} else if !(acquired) {
+			slog.DebugContext(ctx, "Verification Sweep Already in Progress")
+			return nil
+		}
+
+		// --> account(s) created before the token lifetime without a live token can no longer be verified
+		cutoff := pgtype.Timestamptz{Time: time.Now().Add(-v.options.TTL), Valid: true}
+
+		timeouts, e := users.New().Timeout(ctx, tx, cutoff)
+		if e != nil {
+			return e
+		}
+
+		if len(timeouts) > 0 {
+			slog.InfoContext(ctx, "Timed Out Unverified User(s)", slog.Int("count", len(timeouts)), slog.Any("users", timeouts))
+		}
+
+		return nil
+	})
+}
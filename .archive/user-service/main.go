@@ -12,6 +12,7 @@ import (
 	"runtime"
 	"time"
 
+	"github.com/redis/go-redis/v9"
 	"github.com/x-ethr/environment"
 	"github.com/x-ethr/server"
 	"github.com/x-ethr/server/logging"
@@ -27,7 +28,24 @@ import (
 	"github.com/x-ethr/server/metadata"
 
 	"user-service/internal/api/avatar"
+	"user-service/internal/api/deletion"
+	"user-service/internal/api/listing"
+	"user-service/internal/api/profile"
 	"user-service/internal/api/registration"
+	"user-service/internal/api/verify"
+	"user-service/internal/authorization"
+	"user-service/internal/database"
+	"user-service/internal/events"
+	"user-service/internal/mail"
+	"user-service/internal/migration"
+	"user-service/internal/outbox"
+	"user-service/internal/retention"
+	"user-service/internal/storage"
+	"user-service/internal/token"
+	"user-service/internal/token/refresh"
+	"user-service/internal/token/revocation"
+	"user-service/internal/verification"
+	"user-service/models/users"
 )
 
 // header is a dynamically linked string value - defaults to "server" - which represents the server name.
@@ -45,6 +63,49 @@ var ctx, cancel = context.WithCancel(context.Background())
 // port represents a cli flag that sets the server listening port
 var port = flag.String("port", "8080", "Server Listening Port.")
 
+// minimum, maximum represent cli flags that bound the database connection pool's size
+var (
+	minimum = flag.Int("database-minimum-connections", 1, "Minimum Database Pool Connection(s).")
+	maximum = flag.Int("database-maximum-connections", 10, "Maximum Database Pool Connection(s).")
+)
+
+// migrate represents a cli flag that applies pending schema migration(s) before serving
+var migrate = flag.Bool("migrate", false, "Apply Pending Schema Migration(s) on Startup.")
+
+// endpoint represents a cli flag that sets the public email verification endpoint embedded in verification link(s)
+var endpoint = flag.String("verification-url", "http://localhost:8080/verify", "Public Email Verification Endpoint.")
+
+// mailbox represents a cli flag that, if set, writes outbound email(s) to the directory rather than the log
+var mailbox = flag.String("mail-directory", "", "Outbound Email Directory (Defaults to Logging Email(s)).")
+
+// address represents a cli flag that sets the redis instance's address, to which domain event(s) are published
+var address = flag.String("redis-address", "redis.caching.svc.cluster.local:6379", "Redis Address.")
+
+// lifetime represents a cli flag that sets the lifetime of minted access token(s)
+var lifetime = flag.Duration("access-token-ttl", time.Hour, "Access Token Lifetime.")
+
+// grace, retaining represent cli flags that bound a soft-deleted user's restoration window and its lifetime prior to purging
+var (
+	grace     = flag.Duration("deletion-grace", 14*24*time.Hour, "Deleted User Restoration Grace Period.")
+	retaining = flag.Duration("deletion-retention", 30*24*time.Hour, "Deleted User Retention Prior to Purging.")
+)
+
+// directory, location represent cli flags that configure the local-filesystem avatar storage, served by the service itself
+// at "/avatars/" - the location must be the public url routed there
+var (
+	directory = flag.String("avatar-directory", "avatars", "Local Avatar Storage Directory.")
+	location  = flag.String("avatar-url", "http://localhost:8080/avatars", "Public Avatar Base URL.")
+)
+
+// s3endpoint, s3bucket, s3region, s3public represent cli flags that, if an endpoint is set, configure S3-compatible avatar storage in place
+// of the local filesystem. Credentials are sourced from the "AWS_ACCESS_KEY_ID" and "AWS_SECRET_ACCESS_KEY" environment variables.
+var (
+	s3endpoint = flag.String("s3-endpoint", "", "S3-Compatible Object Store Endpoint (Defaults to Local Avatar Storage).")
+	s3bucket   = flag.String("s3-bucket", "avatars", "S3-Compatible Object Store Bucket.")
+	s3region   = flag.String("s3-region", "us-east-1", "S3-Compatible Object Store Region.")
+	s3public   = flag.String("s3-public-url", "", "Public S3 Object Base URL, e.g. a CDN (Defaults to the Bucket URL).")
+)
+
 var logger *slog.Logger
 
 var (
@@ -72,11 +133,113 @@ func main() {
 
 	middlewares.Add(middleware.New().Tracer().Configuration(func(options *tracing.Settings) { options.Tracer = tracer }).Middleware)
 
+	// Database Connection Pool
+	pool, e := database.Pool(ctx, database.Connections(int32(*(minimum)), int32(*(maximum))))
+	if e != nil {
+		slog.ErrorContext(ctx, "Unable to Establish Database Connection Pool", slog.String("error", e.Error()))
+
+		os.Exit(101)
+	}
+
+	defer pool.Close()
+
+	// Schema Migration(s)
+	runner, e := migration.New(pool, users.Migrations)
+	if e != nil {
+		slog.ErrorContext(ctx, "Unable to Load Schema Migration(s)", slog.String("error", e.Error()))
+
+		os.Exit(102)
+	}
+
+	// --> "migrate" subcommand, e.g. "user-service migrate status"
+	if flag.Arg(0) == "migrate" {
+		if e := migration.Command(ctx, runner, flag.Args()[1:], os.Stdout); e != nil {
+			slog.ErrorContext(ctx, "Schema Migration Command Failed", slog.String("error", e.Error()))
+
+			os.Exit(103)
+		}
+
+		return
+	}
+
+	if *(migrate) {
+		if _, e := runner.Up(ctx); e != nil {
+			slog.ErrorContext(ctx, "Unable to Apply Schema Migration(s)", slog.String("error", e.Error()))
+
+			os.Exit(103)
+		}
+	}
+
+	// Email Verification
+	verifier := verification.New(verification.URL(*(endpoint)))
+
+	go verifier.Sweep(ctx, pool)
+
+	var sender mail.Sender = mail.Log{}
+	if *(mailbox) != "" {
+		sender = &mail.File{Directory: *(mailbox)}
+	}
+
+	// Domain Event(s)
+	client := redis.NewClient(&redis.Options{Addr: *(address)})
+
+	defer client.Close()
+
+	publisher := &events.Redis{Client: client}
+
+	// --> domain event(s) are enqueued transactionally, and relayed to the stream by every replica
+	relay := outbox.New(pool, publisher)
+
+	go relay.Run(ctx)
+
+	// Deleted User Retention
+	policy := retention.New(retention.Grace(*(grace)), retention.Retention(*(retaining)))
+
+	go policy.Sweep(ctx, pool)
+
+	// Session Token(s)
+	// --> logout revokes the caller's access token by its "jti"; the bloom filter spares most request(s) the redis round-trip
+	denylist, e := revocation.Bloom(ctx, revocation.Redis(client))
+	if e != nil {
+		slog.ErrorContext(ctx, "Unable to Initialize Token Revocation Denylist", slog.String("error", e.Error()))
+
+		os.Exit(104)
+	}
+
+	// --> refresh token(s) rotate within a family; replaying a consumed token revokes the family
+	manager := refresh.New(refresh.Redis(client), refresh.Access(token.TTL(*(lifetime))), refresh.Denylist(denylist))
+
+	// Avatar Storage
+	var store storage.Storage = &storage.Filesystem{Directory: *(directory), Base: *(location)}
+	if *(s3endpoint) != "" {
+		store = &storage.S3{Endpoint: *(s3endpoint), Region: *(s3region), Bucket: *(s3bucket), AccessKey: os.Getenv("AWS_ACCESS_KEY_ID"), SecretKey: os.Getenv("AWS_SECRET_ACCESS_KEY"), Public: *(s3public)}
+	}
+
+	// --> deleting a user revokes every token issued to it, for as long as any such token could remain valid
+	cutoffs := revocation.RedisCutoffs(client)
+
+	// --> verification token(s) share the signing key, but must never authenticate a request
+	authenticate := authorization.Authenticate(authorization.Exclude(verification.Audience), authorization.Verification(token.SubjectRevocation(cutoffs), token.Revocation(denylist)))
+
 	mux := http.NewServeMux()
 
 	mux.HandleFunc("/", metadata.Handler)
-	mux.HandleFunc("POST /register", registration.Handler)
-	mux.HandleFunc("PATCH /avatar", avatar.Patch)
+	mux.HandleFunc("POST /register", registration.Handler(pool, verifier, sender, manager))
+	mux.HandleFunc("GET /verify", verify.Handler(pool, verifier))
+	mux.Handle("POST /token/refresh", manager.RefreshHandler())
+	mux.Handle("POST /token/revoke", manager.RevokeHandler())
+	mux.Handle("GET /users", authenticate(authorization.Require(authorization.Roles(users.UserAccountTypeROOT))(listing.Handler(pool))))
+	mux.Handle("GET /users/me", authenticate(authorization.Require()(profile.Get(pool))))
+	mux.Handle("PATCH /users/me", authenticate(authorization.Require()(profile.Patch(pool))))
+	mux.Handle("DELETE /users/{id}", authenticate(authorization.Require()(deletion.Delete(pool, cutoffs, manager, token.TTL(*(lifetime))))))
+	mux.Handle("POST /users/{id}/restore", authenticate(authorization.Require(authorization.Roles(users.UserAccountTypeROOT))(deletion.Restore(pool, policy))))
+	mux.Handle("PATCH /avatar", authenticate(authorization.Require()(avatar.Patch(pool))))
+	mux.Handle("PUT /users/me/avatar", authenticate(authorization.Require()(avatar.Upload(pool, store))))
+
+	// --> the gateway rewrites the public prefix (e.g. "/v1/users/avatars/") to "/avatars/" prior to routing to the service
+	if filesystem, ok := store.(*storage.Filesystem); ok {
+		mux.Handle("GET /avatars/", http.StripPrefix("/avatars/", filesystem.Handler()))
+	}
 
 	mux.HandleFunc("GET /health", server.Health)
 
@@ -0,0 +1,12 @@
+package users
+
+import "strings"
+
+// EmailUniqueIndex is the partial unique index guaranteeing an email address belongs to at most one active User.
+const EmailUniqueIndex = "user-email-active-unique-index"
+
+// NormalizeEmail returns the email address' canonical form - trimmed of surrounding whitespace and lowercased - as stored
+// and compared by the User table.
+func NormalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
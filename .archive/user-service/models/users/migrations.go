@@ -0,0 +1,9 @@
+package users
+
+import "embed"
+
+// Migrations contains the versioned "{version}_{name}.up.sql" and "{version}_{name}.down.sql" schema migration(s). sqlc
+// generates against the same directory, ignoring the down migration(s).
+//
+//go:embed migrations/*.sql
+var Migrations embed.FS
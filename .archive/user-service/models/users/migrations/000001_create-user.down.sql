@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS "User";
+
+DROP TYPE IF EXISTS "user-account-type";
+DROP TYPE IF EXISTS "user-verification-status";
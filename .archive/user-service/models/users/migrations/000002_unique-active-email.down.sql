@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS "user-email-active-unique-index";
+
+ALTER TABLE "User" ADD CONSTRAINT "user-email-unique-constraint" UNIQUE (email);
@@ -0,0 +1,12 @@
+--
+-- User-Email-Active-Unique-Index
+--
+
+-- Email addresses are unique among active (non-deleted) user(s) only, case-insensitively; a deleted account's address may
+-- be registered again. The index - not an application-level existence check - guarantees uniqueness under concurrency.
+
+ALTER TABLE "User" DROP CONSTRAINT IF EXISTS "user-email-unique-constraint";
+
+UPDATE "User" SET email = lower(trim(email)) WHERE email <> lower(trim(email));
+
+CREATE UNIQUE INDEX IF NOT EXISTS "user-email-active-unique-index" ON "User" (lower(email)) WHERE (deletion) IS NULL;
@@ -0,0 +1,21 @@
+--
+-- User-Verification
+--
+
+-- Each row records an issued email verification token by its "jti" claim. Tokens are single-use: consumption is recorded
+-- atomically, and an expired or consumed token can't be redeemed.
+
+CREATE TABLE "User-Verification"
+(
+    "id"          text
+        CONSTRAINT "user-verification-id-primary-key" primary key,
+
+    "user"        bigint                   not null
+        CONSTRAINT "user-verification-user-foreign-key" references "User" (id) ON DELETE CASCADE,
+
+    "expiration"  timestamp with time zone not null,
+    "consumption" timestamp with time zone,
+    "creation"    timestamp with time zone default now()
+);
+
+CREATE INDEX IF NOT EXISTS "user-verification-user-index" on "User-Verification" ("user");
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS "user-creation-keyset-index";
+
+ALTER TABLE "User" ALTER COLUMN creation DROP NOT NULL;
@@ -0,0 +1,11 @@
+--
+-- User-Creation-Keyset-Index
+--
+
+-- Listing paginates by ("creation", "id"); the keyset requires a non-null creation timestamp.
+
+UPDATE "User" SET creation = now() WHERE (creation) IS NULL;
+
+ALTER TABLE "User" ALTER COLUMN creation SET NOT NULL;
+
+CREATE INDEX IF NOT EXISTS "user-creation-keyset-index" ON "User" (creation, id) WHERE (deletion) IS NULL;
@@ -0,0 +1,3 @@
+DROP TRIGGER IF EXISTS "outbox-notify-trigger" ON "Outbox";
+DROP FUNCTION IF EXISTS "outbox-notify"();
+DROP TABLE IF EXISTS "Outbox";
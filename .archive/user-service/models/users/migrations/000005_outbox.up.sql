@@ -0,0 +1,39 @@
+--
+-- Outbox
+--
+
+-- Domain event(s) are written to the outbox within the same transaction as the change they describe, and relayed to the
+-- "user-service" Redis stream afterwards. The "aggregate" is the subject User's ID; it deliberately isn't a foreign key,
+-- as a purged User's "user.deleted" event must outlive its row.
+
+CREATE TABLE "Outbox"
+(
+    "id"          bigserial
+        CONSTRAINT "outbox-id-primary-key" primary key,
+
+    "aggregate"   bigint                   not null,
+    "type"        text                     not null,
+    "payload"     jsonb                    not null,
+
+    "creation"    timestamp with time zone default now() not null,
+    "publication" timestamp with time zone
+);
+
+CREATE INDEX IF NOT EXISTS "outbox-pending-index" on "Outbox" (aggregate, id) WHERE (publication) IS NULL;
+CREATE INDEX IF NOT EXISTS "outbox-publication-index" on "Outbox" (publication) WHERE (publication) IS NOT NULL;
+
+-- Wake listening relay(s) upon commit, rather than waiting for their next poll.
+
+CREATE FUNCTION "outbox-notify"() RETURNS trigger AS
+$$
+BEGIN
+    PERFORM pg_notify('outbox', '');
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER "outbox-notify-trigger"
+    AFTER INSERT
+    ON "Outbox"
+    FOR EACH STATEMENT
+EXECUTE FUNCTION "outbox-notify"();
@@ -133,6 +133,15 @@ func AllUserVerificationStatusValues() []UserVerificationStatus {
 	}
 }
 
+type Outbox struct {
+	ID          int64              `db:"id" json:"id"`
+	Aggregate   int64              `db:"aggregate" json:"aggregate"`
+	Type        string             `db:"type" json:"type"`
+	Payload     []byte             `db:"payload" json:"payload"`
+	Creation    pgtype.Timestamptz `db:"creation" json:"creation"`
+	Publication pgtype.Timestamptz `db:"publication" json:"publication"`
+}
+
 type User struct {
 	ID                 int64                  `db:"id" json:"id"`
 	Name               *string                `db:"name" json:"name"`
@@ -147,3 +156,11 @@ type User struct {
 	Modification       pgtype.Timestamptz     `db:"modification" json:"modification"`
 	Deletion           pgtype.Timestamptz     `db:"deletion" json:"deletion"`
 }
+
+type UserVerification struct {
+	ID          string             `db:"id" json:"id"`
+	User        int64              `db:"user" json:"user"`
+	Expiration  pgtype.Timestamptz `db:"expiration" json:"expiration"`
+	Consumption pgtype.Timestamptz `db:"consumption" json:"consumption"`
+	Creation    pgtype.Timestamptz `db:"creation" json:"creation"`
+}
@@ -0,0 +1,130 @@
+package users
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// columns lists the [User] column(s) in scan order.
+const columns = `id, name, "display-name", "account-type", email, username, avatar, "verification-status", marketing, creation, modification, deletion`
+
+// Filter narrows the active User(s) considered by [Queries.Page] and [Queries.Tally]. Nil fields don't filter.
+type Filter struct {
+	AccountType        *UserAccountType
+	VerificationStatus *UserVerificationStatus
+	Marketing          *bool
+
+	After  *time.Time // After, if non-nil, includes User(s) created at or after the instant.
+	Before *time.Time // Before, if non-nil, includes User(s) created strictly before the instant.
+}
+
+// where returns the filter's SQL condition(s) - always excluding deleted User(s) - and positional argument(s).
+func (f *Filter) where() (conditions []string, arguments []interface{}) {
+	conditions = []string{"(deletion) IS NULL"}
+
+	add := func(condition string, argument interface{}) {
+		arguments = append(arguments, argument)
+		conditions = append(conditions, fmt.Sprintf(condition, len(arguments)))
+	}
+
+	if f.AccountType != nil {
+		add(`"account-type" = $%d`, *(f.AccountType))
+	}
+
+	if f.VerificationStatus != nil {
+		add(`"verification-status" = $%d`, *(f.VerificationStatus))
+	}
+
+	if f.Marketing != nil {
+		add(`marketing = $%d`, *(f.Marketing))
+	}
+
+	if f.After != nil {
+		add(`creation >= $%d`, *(f.After))
+	}
+
+	if f.Before != nil {
+		add(`creation < $%d`, *(f.Before))
+	}
+
+	return conditions, arguments
+}
+
+// Cursor represents a keyset position: the last User of the previous page.
+type Cursor struct {
+	Creation time.Time
+	ID       int64
+}
+
+type PageParams struct {
+	Filter
+
+	Cursor     *Cursor // Cursor, if non-nil, resumes after the position.
+	Descending bool    // Descending orders newest-first.
+	Limit      int32
+}
+
+// Page returns up to [PageParams.Limit] filtered, active User(s) ordered by ("creation", "id"), via keyset pagination.
+//
+// Unlike the sqlc-generated queries, Page and [Queries.Tally] build their statement(s) dynamically, as the filter(s) and
+// sort direction vary per request.
+func (q *Queries) Page(ctx context.Context, db DBTX, arg *PageParams) ([]User, error) {
+	conditions, arguments := arg.Filter.where()
+
+	direction, comparison := "ASC", ">"
+	if arg.Descending {
+		direction, comparison = "DESC", "<"
+	}
+
+	if arg.Cursor != nil {
+		arguments = append(arguments, arg.Cursor.Creation, arg.Cursor.ID)
+		conditions = append(conditions, fmt.Sprintf("(creation, id) %s ($%d, $%d)", comparison, len(arguments)-1, len(arguments)))
+	}
+
+	arguments = append(arguments, arg.Limit)
+
+	statement := fmt.Sprintf(`SELECT %s FROM "User" WHERE %s ORDER BY creation %s, id %s LIMIT $%d`, columns, strings.Join(conditions, " AND "), direction, direction, len(arguments))
+
+	rows, err := db.Query(ctx, statement, arguments...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []User{}
+	for rows.Next() {
+		var i User
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.DisplayName,
+			&i.AccountType,
+			&i.Email,
+			&i.Username,
+			&i.Avatar,
+			&i.VerificationStatus,
+			&i.Marketing,
+			&i.Creation,
+			&i.Modification,
+			&i.Deletion,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+// Tally returns the total number of filtered, active User(s), irrespective of pagination.
+func (q *Queries) Tally(ctx context.Context, db DBTX, filter *Filter) (int64, error) {
+	conditions, arguments := filter.where()
+
+	row := db.QueryRow(ctx, fmt.Sprintf(`SELECT count(*) FROM "User" WHERE %s`, strings.Join(conditions, " AND ")), arguments...)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
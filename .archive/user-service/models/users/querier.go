@@ -6,23 +6,49 @@ package users
 
 import (
 	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
 )
 
 type Querier interface {
-	// All returns the total number of User records, including deleted record(s).
-	All(ctx context.Context, db DBTX) (int64, error)
 	// Attributes will use the user's [User.ID] to hydrate all available User attribute(s). Note that the following call is more taxing on the database.
 	Attributes(ctx context.Context, db DBTX, id int64) (User, error)
+	// ConsumeVerification marks an unexpired, unconsumed verification token as used, returning its User's ID. No row is returned if the token was already used or has expired.
+	ConsumeVerification(ctx context.Context, db DBTX, id string) (int64, error)
 	// Count returns 0 or 1 depending on if a User record matching the provided email exists.
 	Count(ctx context.Context, db DBTX, email string) (int64, error)
 	Create(ctx context.Context, db DBTX, arg *CreateParams) (User, error)
-	// List returns all active User record(s).
-	List(ctx context.Context, db DBTX) ([]User, error)
-	// Total returns the total number of User records, excluding deleted record(s).
-	Total(ctx context.Context, db DBTX) (int64, error)
-	UpdateUserAvatar(ctx context.Context, db DBTX, arg *UpdateUserAvatarParams) error
-	// Users returns all User record(s).
-	Users(ctx context.Context, db DBTX) ([]User, error)
+	// CreateVerification records an issued verification token.
+	CreateVerification(ctx context.Context, db DBTX, arg *CreateVerificationParams) error
+	// Enqueue writes a domain event to the Outbox; callers must use the transaction recording the event's change.
+	Enqueue(ctx context.Context, db DBTX, arg *EnqueueParams) error
+	// LockProfile hydrates an active User by its (normalized) email address, locking the row until the transaction ends.
+	LockProfile(ctx context.Context, db DBTX, email string) (User, error)
+	// Lookup hydrates a User by [User.ID], including soft-deleted User(s).
+	Lookup(ctx context.Context, db DBTX, id int64) (User, error)
+	// MarkPublished records the Outbox event(s) as relayed.
+	MarkPublished(ctx context.Context, db DBTX, ids []int64) error
+	// Pending locks up to limit unpublished Outbox event(s), in order, skipping those locked by another relay. An event is only
+	// eligible once every earlier event of its aggregate has been published, preserving per-aggregate order across relay(s).
+	Pending(ctx context.Context, db DBTX, limit int32) ([]Outbox, error)
+	// Profile hydrates an active User by its (normalized) email address.
+	Profile(ctx context.Context, db DBTX, email string) (User, error)
+	// Prune deletes Outbox event(s) published before the cutoff.
+	Prune(ctx context.Context, db DBTX, cutoff pgtype.Timestamptz) (int64, error)
+	// Purge hard-deletes up to size User(s) soft-deleted before the cutoff, oldest first - returning the removed row(s).
+	Purge(ctx context.Context, db DBTX, arg *PurgeParams) ([]PurgeRow, error)
+	// Restore reverts a User's soft-deletion, provided it was deleted after the cutoff.
+	Restore(ctx context.Context, db DBTX, arg *RestoreParams) (User, error)
+	// SoftDelete marks an active User as deleted. The row is retained - and restorable - until purged.
+	SoftDelete(ctx context.Context, db DBTX, id int64) (User, error)
+	// Timeout transitions PENDING User(s) created before the cutoff, and without an unexpired verification token, to TIMEOUT - returning their ID(s).
+	Timeout(ctx context.Context, db DBTX, cutoff pgtype.Timestamptz) ([]int64, error)
+	// UpdateProfile overwrites an active User's profile attribute(s). Modification uses the wall clock, rather than the
+	// transaction's start, so that it - and the ETag derived from it - strictly advances.
+	UpdateProfile(ctx context.Context, db DBTX, arg *UpdateProfileParams) (User, error)
+	UpdateUserAvatar(ctx context.Context, db DBTX, arg *UpdateUserAvatarParams) (User, error)
+	// Verify transitions a PENDING User to VERIFIED.
+	Verify(ctx context.Context, db DBTX, id int64) (int64, error)
 }
 
 var _ Querier = (*Queries)(nil)
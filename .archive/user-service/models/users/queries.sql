@@ -9,25 +9,88 @@ LIMIT 1;
 -- name: Create :one
 INSERT INTO "User" (email, avatar) VALUES ($1, $2) RETURNING *;
 
--- name: UpdateUserAvatar :exec
-UPDATE "User" SET avatar = $2 WHERE (email) = $1 AND (deletion) IS NULL;
+-- name: UpdateUserAvatar :one
+UPDATE "User" SET avatar = $2, modification = clock_timestamp() WHERE (email) = $1 AND (deletion) IS NULL RETURNING *;
 
 -- name: Count :one
 -- Count returns 0 or 1 depending on if a User record matching the provided email exists.
 SELECT count(*) FROM "User" WHERE (email) = sqlc.arg(email)::text AND (deletion) IS NULL;
 
--- name: Total :one
--- Total returns the total number of User records, excluding deleted record(s).
-SELECT count(*) FROM "User" WHERE (deletion) IS NULL;
+-- name: CreateVerification :exec
+-- CreateVerification records an issued verification token.
+INSERT INTO "User-Verification" (id, "user", expiration) VALUES ($1, $2, $3);
 
--- name: All :one
--- All returns the total number of User records, including deleted record(s).
-SELECT count(*) FROM "User";
+-- name: ConsumeVerification :one
+-- ConsumeVerification marks an unexpired, unconsumed verification token as used, returning its User's ID. No row is returned if the token was already used or has expired.
+UPDATE "User-Verification" SET consumption = now() WHERE (id) = $1 AND (consumption) IS NULL AND (expiration) > now() RETURNING "user";
 
--- name: List :many
--- List returns all active User record(s).
-SELECT * FROM "User" WHERE (deletion) IS NULL;
+-- name: Verify :execrows
+-- Verify transitions a PENDING User to VERIFIED.
+UPDATE "User" SET "verification-status" = 'VERIFIED', modification = now() WHERE (id) = $1 AND "verification-status" = 'PENDING' AND (deletion) IS NULL;
 
--- name: Users :many
--- Users returns all User record(s).
-SELECT * FROM "User";
+-- name: Timeout :many
+-- Timeout transitions PENDING User(s) created before the cutoff, and without an unexpired verification token, to TIMEOUT - returning their ID(s).
+UPDATE "User" SET "verification-status" = 'TIMEOUT', modification = now()
+WHERE "verification-status" = 'PENDING'
+  AND (deletion) IS NULL
+  AND (creation) < sqlc.arg(cutoff)::timestamptz
+  AND NOT EXISTS (SELECT 1 FROM "User-Verification" WHERE "User-Verification"."user" = "User".id AND "User-Verification".expiration > now())
+RETURNING id;
+
+-- name: Lookup :one
+-- Lookup hydrates a User by [User.ID], including soft-deleted User(s).
+SELECT * FROM "User" WHERE (id) = $1 LIMIT 1;
+
+-- name: SoftDelete :one
+-- SoftDelete marks an active User as deleted. The row is retained - and restorable - until purged.
+UPDATE "User" SET deletion = now(), modification = now() WHERE (id) = $1 AND (deletion) IS NULL RETURNING *;
+
+-- name: Restore :one
+-- Restore reverts a User's soft-deletion, provided it was deleted after the cutoff.
+UPDATE "User" SET deletion = NULL, modification = now()
+WHERE (id) = sqlc.arg(id)::bigint
+  AND (deletion) > sqlc.arg(cutoff)::timestamptz
+RETURNING *;
+
+-- name: Purge :many
+-- Purge hard-deletes up to size User(s) soft-deleted before the cutoff, oldest first - returning the removed row(s).
+DELETE FROM "User"
+WHERE id IN (SELECT id FROM "User" WHERE (deletion) < sqlc.arg(cutoff)::timestamptz ORDER BY deletion LIMIT sqlc.arg(size)::int)
+RETURNING id, email, deletion;
+
+-- name: Profile :one
+-- Profile hydrates an active User by its (normalized) email address.
+SELECT * FROM "User" WHERE (email) = $1 AND (deletion) IS NULL LIMIT 1;
+
+-- name: LockProfile :one
+-- LockProfile hydrates an active User by its (normalized) email address, locking the row until the transaction ends.
+SELECT * FROM "User" WHERE (email) = $1 AND (deletion) IS NULL LIMIT 1 FOR UPDATE;
+
+-- name: UpdateProfile :one
+-- UpdateProfile overwrites an active User's profile attribute(s). Modification uses the wall clock, rather than the
+-- transaction's start, so that it - and the ETag derived from it - strictly advances.
+UPDATE "User" SET name = $2, "display-name" = $3, username = $4, marketing = $5, modification = clock_timestamp()
+WHERE (id) = $1 AND (deletion) IS NULL
+RETURNING *;
+
+-- name: Enqueue :exec
+-- Enqueue writes a domain event to the Outbox; callers must use the transaction recording the event's change.
+INSERT INTO "Outbox" (aggregate, type, payload) VALUES ($1, $2, $3);
+
+-- name: Pending :many
+-- Pending locks up to limit unpublished Outbox event(s), in order, skipping those locked by another relay. An event is only
+-- eligible once every earlier event of its aggregate has been published, preserving per-aggregate order across relay(s).
+SELECT * FROM "Outbox" AS o
+WHERE (o.publication) IS NULL
+  AND NOT EXISTS (SELECT 1 FROM "Outbox" AS p WHERE p.aggregate = o.aggregate AND (p.publication) IS NULL AND p.id < o.id)
+ORDER BY o.id
+LIMIT $1
+FOR UPDATE SKIP LOCKED;
+
+-- name: MarkPublished :exec
+-- MarkPublished records the Outbox event(s) as relayed.
+UPDATE "Outbox" SET publication = now() WHERE id = ANY(sqlc.arg(ids)::bigint[]);
+
+-- name: Prune :execrows
+-- Prune deletes Outbox event(s) published before the cutoff.
+DELETE FROM "Outbox" WHERE (publication) < sqlc.arg(cutoff)::timestamptz;
@@ -7,19 +7,9 @@ package users
 
 import (
 	"context"
-)
-
-const all = `-- name: All :one
-SELECT count(*) FROM "User"
-`
 
-// All returns the total number of User records, including deleted record(s).
-func (q *Queries) All(ctx context.Context, db DBTX) (int64, error) {
-	row := db.QueryRow(ctx, all)
-	var count int64
-	err := row.Scan(&count)
-	return count, err
-}
+	"github.com/jackc/pgx/v5/pgtype"
+)
 
 const attributes = `-- name: Attributes :one
 SELECT id, name, "display-name", "account-type", email, username, avatar, "verification-status", marketing, creation, modification, deletion
@@ -50,6 +40,18 @@ func (q *Queries) Attributes(ctx context.Context, db DBTX, id int64) (User, erro
 	return i, err
 }
 
+const consumeVerification = `-- name: ConsumeVerification :one
+UPDATE "User-Verification" SET consumption = now() WHERE (id) = $1 AND (consumption) IS NULL AND (expiration) > now() RETURNING "user"
+`
+
+// ConsumeVerification marks an unexpired, unconsumed verification token as used, returning its User's ID. No row is returned if the token was already used or has expired.
+func (q *Queries) ConsumeVerification(ctx context.Context, db DBTX, id string) (int64, error) {
+	row := db.QueryRow(ctx, consumeVerification, id)
+	var user int64
+	err := row.Scan(&user)
+	return user, err
+}
+
 const count = `-- name: Count :one
 SELECT count(*) FROM "User" WHERE (email) = $1::text AND (deletion) IS NULL
 `
@@ -91,33 +93,125 @@ func (q *Queries) Create(ctx context.Context, db DBTX, arg *CreateParams) (User,
 	return i, err
 }
 
-const list = `-- name: List :many
-SELECT id, name, "display-name", "account-type", email, username, avatar, "verification-status", marketing, creation, modification, deletion FROM "User" WHERE (deletion) IS NULL
+const createVerification = `-- name: CreateVerification :exec
+INSERT INTO "User-Verification" (id, "user", expiration) VALUES ($1, $2, $3)
+`
+
+type CreateVerificationParams struct {
+	ID         string             `db:"id" json:"id"`
+	User       int64              `db:"user" json:"user"`
+	Expiration pgtype.Timestamptz `db:"expiration" json:"expiration"`
+}
+
+// CreateVerification records an issued verification token.
+func (q *Queries) CreateVerification(ctx context.Context, db DBTX, arg *CreateVerificationParams) error {
+	_, err := db.Exec(ctx, createVerification, arg.ID, arg.User, arg.Expiration)
+	return err
+}
+
+const enqueue = `-- name: Enqueue :exec
+INSERT INTO "Outbox" (aggregate, type, payload) VALUES ($1, $2, $3)
+`
+
+type EnqueueParams struct {
+	Aggregate int64  `db:"aggregate" json:"aggregate"`
+	Type      string `db:"type" json:"type"`
+	Payload   []byte `db:"payload" json:"payload"`
+}
+
+// Enqueue writes a domain event to the Outbox; callers must use the transaction recording the event's change.
+func (q *Queries) Enqueue(ctx context.Context, db DBTX, arg *EnqueueParams) error {
+	_, err := db.Exec(ctx, enqueue, arg.Aggregate, arg.Type, arg.Payload)
+	return err
+}
+
+const lockProfile = `-- name: LockProfile :one
+SELECT id, name, "display-name", "account-type", email, username, avatar, "verification-status", marketing, creation, modification, deletion FROM "User" WHERE (email) = $1 AND (deletion) IS NULL LIMIT 1 FOR UPDATE
 `
 
-// List returns all active User record(s).
-func (q *Queries) List(ctx context.Context, db DBTX) ([]User, error) {
-	rows, err := db.Query(ctx, list)
+// LockProfile hydrates an active User by its (normalized) email address, locking the row until the transaction ends.
+func (q *Queries) LockProfile(ctx context.Context, db DBTX, email string) (User, error) {
+	row := db.QueryRow(ctx, lockProfile, email)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.DisplayName,
+		&i.AccountType,
+		&i.Email,
+		&i.Username,
+		&i.Avatar,
+		&i.VerificationStatus,
+		&i.Marketing,
+		&i.Creation,
+		&i.Modification,
+		&i.Deletion,
+	)
+	return i, err
+}
+
+const lookup = `-- name: Lookup :one
+SELECT id, name, "display-name", "account-type", email, username, avatar, "verification-status", marketing, creation, modification, deletion FROM "User" WHERE (id) = $1 LIMIT 1
+`
+
+// Lookup hydrates a User by [User.ID], including soft-deleted User(s).
+func (q *Queries) Lookup(ctx context.Context, db DBTX, id int64) (User, error) {
+	row := db.QueryRow(ctx, lookup, id)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.DisplayName,
+		&i.AccountType,
+		&i.Email,
+		&i.Username,
+		&i.Avatar,
+		&i.VerificationStatus,
+		&i.Marketing,
+		&i.Creation,
+		&i.Modification,
+		&i.Deletion,
+	)
+	return i, err
+}
+
+const markPublished = `-- name: MarkPublished :exec
+UPDATE "Outbox" SET publication = now() WHERE id = ANY($1::bigint[])
+`
+
+// MarkPublished records the Outbox event(s) as relayed.
+func (q *Queries) MarkPublished(ctx context.Context, db DBTX, ids []int64) error {
+	_, err := db.Exec(ctx, markPublished, ids)
+	return err
+}
+
+const pending = `-- name: Pending :many
+SELECT o.id, o.aggregate, o.type, o.payload, o.creation, o.publication FROM "Outbox" AS o
+WHERE (o.publication) IS NULL
+  AND NOT EXISTS (SELECT 1 FROM "Outbox" AS p WHERE p.aggregate = o.aggregate AND (p.publication) IS NULL AND p.id < o.id)
+ORDER BY o.id
+LIMIT $1
+FOR UPDATE SKIP LOCKED
+`
+
+// Pending locks up to limit unpublished Outbox event(s), in order, skipping those locked by another relay. An event is only
+// eligible once every earlier event of its aggregate has been published, preserving per-aggregate order across relay(s).
+func (q *Queries) Pending(ctx context.Context, db DBTX, limit int32) ([]Outbox, error) {
+	rows, err := db.Query(ctx, pending, limit)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	items := []User{}
+	items := []Outbox{}
 	for rows.Next() {
-		var i User
+		var i Outbox
 		if err := rows.Scan(
 			&i.ID,
-			&i.Name,
-			&i.DisplayName,
-			&i.AccountType,
-			&i.Email,
-			&i.Username,
-			&i.Avatar,
-			&i.VerificationStatus,
-			&i.Marketing,
+			&i.Aggregate,
+			&i.Type,
+			&i.Payload,
 			&i.Creation,
-			&i.Modification,
-			&i.Deletion,
+			&i.Publication,
 		); err != nil {
 			return nil, err
 		}
@@ -129,60 +223,72 @@ func (q *Queries) List(ctx context.Context, db DBTX) ([]User, error) {
 	return items, nil
 }
 
-const total = `-- name: Total :one
-SELECT count(*) FROM "User" WHERE (deletion) IS NULL
+const profile = `-- name: Profile :one
+SELECT id, name, "display-name", "account-type", email, username, avatar, "verification-status", marketing, creation, modification, deletion FROM "User" WHERE (email) = $1 AND (deletion) IS NULL LIMIT 1
 `
 
-// Total returns the total number of User records, excluding deleted record(s).
-func (q *Queries) Total(ctx context.Context, db DBTX) (int64, error) {
-	row := db.QueryRow(ctx, total)
-	var count int64
-	err := row.Scan(&count)
-	return count, err
+// Profile hydrates an active User by its (normalized) email address.
+func (q *Queries) Profile(ctx context.Context, db DBTX, email string) (User, error) {
+	row := db.QueryRow(ctx, profile, email)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.DisplayName,
+		&i.AccountType,
+		&i.Email,
+		&i.Username,
+		&i.Avatar,
+		&i.VerificationStatus,
+		&i.Marketing,
+		&i.Creation,
+		&i.Modification,
+		&i.Deletion,
+	)
+	return i, err
 }
 
-const updateUserAvatar = `-- name: UpdateUserAvatar :exec
-UPDATE "User" SET avatar = $2 WHERE (email) = $1 AND (deletion) IS NULL
+const prune = `-- name: Prune :execrows
+DELETE FROM "Outbox" WHERE (publication) < $1::timestamptz
 `
 
-type UpdateUserAvatarParams struct {
-	Email  string  `db:"email" json:"email"`
-	Avatar *string `db:"avatar" json:"avatar"`
+// Prune deletes Outbox event(s) published before the cutoff.
+func (q *Queries) Prune(ctx context.Context, db DBTX, cutoff pgtype.Timestamptz) (int64, error) {
+	result, err := db.Exec(ctx, prune, cutoff)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
 }
 
-func (q *Queries) UpdateUserAvatar(ctx context.Context, db DBTX, arg *UpdateUserAvatarParams) error {
-	_, err := db.Exec(ctx, updateUserAvatar, arg.Email, arg.Avatar)
-	return err
+const purge = `-- name: Purge :many
+DELETE FROM "User"
+WHERE id IN (SELECT id FROM "User" WHERE (deletion) < $1::timestamptz ORDER BY deletion LIMIT $2::int)
+RETURNING id, email, deletion
+`
+
+type PurgeParams struct {
+	Cutoff pgtype.Timestamptz `db:"cutoff" json:"cutoff"`
+	Size   int32              `db:"size" json:"size"`
 }
 
-const users = `-- name: Users :many
-SELECT id, name, "display-name", "account-type", email, username, avatar, "verification-status", marketing, creation, modification, deletion FROM "User"
-`
+type PurgeRow struct {
+	ID       int64              `db:"id" json:"id"`
+	Email    string             `db:"email" json:"email"`
+	Deletion pgtype.Timestamptz `db:"deletion" json:"deletion"`
+}
 
-// Users returns all User record(s).
-func (q *Queries) Users(ctx context.Context, db DBTX) ([]User, error) {
-	rows, err := db.Query(ctx, users)
+// Purge hard-deletes up to size User(s) soft-deleted before the cutoff, oldest first - returning the removed row(s).
+func (q *Queries) Purge(ctx context.Context, db DBTX, arg *PurgeParams) ([]PurgeRow, error) {
+	rows, err := db.Query(ctx, purge, arg.Cutoff, arg.Size)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	items := []User{}
+	items := []PurgeRow{}
 	for rows.Next() {
-		var i User
-		if err := rows.Scan(
-			&i.ID,
-			&i.Name,
-			&i.DisplayName,
-			&i.AccountType,
-			&i.Email,
-			&i.Username,
-			&i.Avatar,
-			&i.VerificationStatus,
-			&i.Marketing,
-			&i.Creation,
-			&i.Modification,
-			&i.Deletion,
-		); err != nil {
+		var i PurgeRow
+		if err := rows.Scan(&i.ID, &i.Email, &i.Deletion); err != nil {
 			return nil, err
 		}
 		items = append(items, i)
@@ -192,3 +298,175 @@ func (q *Queries) Users(ctx context.Context, db DBTX) ([]User, error) {
 	}
 	return items, nil
 }
+
+const restore = `-- name: Restore :one
+UPDATE "User" SET deletion = NULL, modification = now()
+WHERE (id) = $1::bigint
+  AND (deletion) > $2::timestamptz
+RETURNING id, name, "display-name", "account-type", email, username, avatar, "verification-status", marketing, creation, modification, deletion
+`
+
+type RestoreParams struct {
+	ID     int64              `db:"id" json:"id"`
+	Cutoff pgtype.Timestamptz `db:"cutoff" json:"cutoff"`
+}
+
+// Restore reverts a User's soft-deletion, provided it was deleted after the cutoff.
+func (q *Queries) Restore(ctx context.Context, db DBTX, arg *RestoreParams) (User, error) {
+	row := db.QueryRow(ctx, restore, arg.ID, arg.Cutoff)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.DisplayName,
+		&i.AccountType,
+		&i.Email,
+		&i.Username,
+		&i.Avatar,
+		&i.VerificationStatus,
+		&i.Marketing,
+		&i.Creation,
+		&i.Modification,
+		&i.Deletion,
+	)
+	return i, err
+}
+
+const softDelete = `-- name: SoftDelete :one
+UPDATE "User" SET deletion = now(), modification = now() WHERE (id) = $1 AND (deletion) IS NULL RETURNING id, name, "display-name", "account-type", email, username, avatar, "verification-status", marketing, creation, modification, deletion
+`
+
+// SoftDelete marks an active User as deleted. The row is retained - and restorable - until purged.
+func (q *Queries) SoftDelete(ctx context.Context, db DBTX, id int64) (User, error) {
+	row := db.QueryRow(ctx, softDelete, id)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.DisplayName,
+		&i.AccountType,
+		&i.Email,
+		&i.Username,
+		&i.Avatar,
+		&i.VerificationStatus,
+		&i.Marketing,
+		&i.Creation,
+		&i.Modification,
+		&i.Deletion,
+	)
+	return i, err
+}
+
+const timeout = `-- name: Timeout :many
+UPDATE "User" SET "verification-status" = 'TIMEOUT', modification = now()
+WHERE "verification-status" = 'PENDING'
+  AND (deletion) IS NULL
+  AND (creation) < $1::timestamptz
+  AND NOT EXISTS (SELECT 1 FROM "User-Verification" WHERE "User-Verification"."user" = "User".id AND "User-Verification".expiration > now())
+RETURNING id
+`
+
+// Timeout transitions PENDING User(s) created before the cutoff, and without an unexpired verification token, to TIMEOUT - returning their ID(s).
+func (q *Queries) Timeout(ctx context.Context, db DBTX, cutoff pgtype.Timestamptz) ([]int64, error) {
+	rows, err := db.Query(ctx, timeout, cutoff)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []int64{}
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		items = append(items, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const updateProfile = `-- name: UpdateProfile :one
+UPDATE "User" SET name = $2, "display-name" = $3, username = $4, marketing = $5, modification = clock_timestamp()
+WHERE (id) = $1 AND (deletion) IS NULL
+RETURNING id, name, "display-name", "account-type", email, username, avatar, "verification-status", marketing, creation, modification, deletion
+`
+
+type UpdateProfileParams struct {
+	ID          int64   `db:"id" json:"id"`
+	Name        *string `db:"name" json:"name"`
+	DisplayName *string `db:"display-name" json:"display-name"`
+	Username    *string `db:"username" json:"username"`
+	Marketing   bool    `db:"marketing" json:"marketing"`
+}
+
+// UpdateProfile overwrites an active User's profile attribute(s). Modification uses the wall clock, rather than the
+// transaction's start, so that it - and the ETag derived from it - strictly advances.
+func (q *Queries) UpdateProfile(ctx context.Context, db DBTX, arg *UpdateProfileParams) (User, error) {
+	row := db.QueryRow(ctx, updateProfile,
+		arg.ID,
+		arg.Name,
+		arg.DisplayName,
+		arg.Username,
+		arg.Marketing,
+	)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.DisplayName,
+		&i.AccountType,
+		&i.Email,
+		&i.Username,
+		&i.Avatar,
+		&i.VerificationStatus,
+		&i.Marketing,
+		&i.Creation,
+		&i.Modification,
+		&i.Deletion,
+	)
+	return i, err
+}
+
+const updateUserAvatar = `-- name: UpdateUserAvatar :one
+UPDATE "User" SET avatar = $2, modification = clock_timestamp() WHERE (email) = $1 AND (deletion) IS NULL RETURNING id, name, "display-name", "account-type", email, username, avatar, "verification-status", marketing, creation, modification, deletion
+`
+
+type UpdateUserAvatarParams struct {
+	Email  string  `db:"email" json:"email"`
+	Avatar *string `db:"avatar" json:"avatar"`
+}
+
+func (q *Queries) UpdateUserAvatar(ctx context.Context, db DBTX, arg *UpdateUserAvatarParams) (User, error) {
+	row := db.QueryRow(ctx, updateUserAvatar, arg.Email, arg.Avatar)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.DisplayName,
+		&i.AccountType,
+		&i.Email,
+		&i.Username,
+		&i.Avatar,
+		&i.VerificationStatus,
+		&i.Marketing,
+		&i.Creation,
+		&i.Modification,
+		&i.Deletion,
+	)
+	return i, err
+}
+
+const verify = `-- name: Verify :execrows
+UPDATE "User" SET "verification-status" = 'VERIFIED', modification = now() WHERE (id) = $1 AND "verification-status" = 'PENDING' AND (deletion) IS NULL
+`
+
+// Verify transitions a PENDING User to VERIFIED.
+func (q *Queries) Verify(ctx context.Context, db DBTX, id int64) (int64, error) {
+	result, err := db.Exec(ctx, verify, id)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
@@ -0,0 +1,24 @@
+package users
+
+import (
+	"regexp"
+	"strings"
+)
+
+// UsernameUniqueConstraint is the unique constraint guaranteeing a username belongs to at most one User.
+const UsernameUniqueConstraint = "user-username-unique-constraint"
+
+// username matches a valid, normalized username: 3 to 32 lowercase alphanumeric, ".", "_" or "-" character(s), starting
+// with an alphanumeric.
+var username = regexp.MustCompile(`^[a-z0-9][a-z0-9._-]{2,31}$`)
+
+// NormalizeUsername returns the username's canonical form - trimmed of surrounding whitespace and lowercased - as stored
+// by the User table.
+func NormalizeUsername(value string) string {
+	return strings.ToLower(strings.TrimSpace(value))
+}
+
+// ValidUsername reports whether the normalized username is well-formed.
+func ValidUsername(value string) bool {
+	return username.MatchString(value)
+}
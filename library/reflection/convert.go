@@ -0,0 +1,343 @@
+package reflection
+
+import (
+	"encoding"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+var (
+	ErrUnsupportedKind = errors.New("unsupported kind")
+	ErrCycle           = errors.New("reference cycle detected")
+	ErrNotStruct       = errors.New("input is not a struct or struct pointer")
+)
+
+var (
+	marshaler     = reflect.TypeFor[json.Marshaler]()
+	textmarshaler = reflect.TypeFor[encoding.TextMarshaler]()
+)
+
+// leaf reports whether the type serializes itself (e.g. [time.Time], pgtype.Timestamptz), in which case its value is kept
+// as-is rather than being walked.
+func leaf(typ reflect.Type) bool {
+	if typ.Implements(marshaler) || typ.Implements(textmarshaler) {
+		return true
+	}
+
+	pointer := reflect.PointerTo(typ)
+
+	return pointer.Implements(marshaler) || pointer.Implements(textmarshaler)
+}
+
+// visit identifies a reference on the walker's current path.
+type visit struct {
+	pointer uintptr
+	typ     reflect.Type
+}
+
+// walker converts arbitrary value graphs into map[string]interface{}, []interface{} and scalar value(s).
+type walker struct {
+	options  *Options
+	path     []string
+	visiting map[visit]struct{}
+}
+
+func (w *walker) errorf(e error, format string, arguments ...interface{}) error {
+	path := strings.Join(w.path, ".")
+	if path == "" {
+		path = "(root)"
+	}
+
+	return fmt.Errorf("%w: %s (path: %s)", e, fmt.Sprintf(format, arguments...), path)
+}
+
+// enter records a reference on the current path, returning false if it's already being walked.
+func (w *walker) enter(v reflect.Value) bool {
+	key := visit{pointer: v.Pointer(), typ: v.Type()}
+	if _, ok := w.visiting[key]; ok {
+		return false
+	}
+
+	w.visiting[key] = struct{}{}
+
+	return true
+}
+
+func (w *walker) leave(v reflect.Value) {
+	delete(w.visiting, visit{pointer: v.Pointer(), typ: v.Type()})
+}
+
+// value converts a single value.
+func (w *walker) value(v reflect.Value) (interface{}, error) {
+	switch v.Kind() {
+	case reflect.Invalid:
+		return nil, nil
+	case reflect.Pointer:
+		if v.IsNil() {
+			return nil, nil
+		}
+
+		if !(w.enter(v)) {
+			return nil, w.errorf(ErrCycle, "%s", v.Type())
+		}
+
+		defer w.leave(v)
+
+		return w.value(v.Elem())
+	case reflect.Interface:
+		if v.IsNil() {
+			return nil, nil
+		}
+
+		return w.value(v.Elem())
+	case reflect.Struct:
+		if cacheable(v.Type()) {
+			return v.Interface(), nil
+		}
+
+		return w.structure(v)
+	case reflect.Slice:
+		if v.IsNil() {
+			return nil, nil
+		}
+
+		// --> []byte is serialized as a base64 string by encoding/json; keep it intact
+		if v.Type().Elem().Kind() == reflect.Uint8 || cacheable(v.Type()) {
+			return v.Interface(), nil
+		}
+
+		if !(w.enter(v)) {
+			return nil, w.errorf(ErrCycle, "%s", v.Type())
+		}
+
+		defer w.leave(v)
+
+		return w.sequence(v)
+	case reflect.Array:
+		if cacheable(v.Type()) {
+			return v.Interface(), nil
+		}
+
+		return w.sequence(v)
+	case reflect.Map:
+		if v.IsNil() {
+			return nil, nil
+		}
+
+		if cacheable(v.Type()) {
+			return v.Interface(), nil
+		}
+
+		if !(w.enter(v)) {
+			return nil, w.errorf(ErrCycle, "%s", v.Type())
+		}
+
+		defer w.leave(v)
+
+		return w.mapping(v)
+	case reflect.Chan, reflect.Func, reflect.UnsafePointer:
+		return nil, w.errorf(ErrUnsupportedKind, "%s", v.Kind())
+	}
+
+	return v.Interface(), nil
+}
+
+// sequence converts each element of a slice or array.
+func (w *walker) sequence(v reflect.Value) ([]interface{}, error) {
+	result := make([]interface{}, v.Len())
+	for i := range result {
+		w.path = append(w.path, fmt.Sprintf("[%d]", i))
+
+		value, e := w.value(v.Index(i))
+
+		w.path = w.path[:len(w.path)-1]
+
+		if e != nil {
+			return nil, e
+		}
+
+		result[i] = value
+	}
+
+	return result, nil
+}
+
+// mapping converts each value of a map. Keys are stringified following encoding/json's rule(s).
+func (w *walker) mapping(v reflect.Value) (map[string]interface{}, error) {
+	result := make(map[string]interface{}, v.Len())
+
+	iterator := v.MapRange()
+	for iterator.Next() {
+		var name string
+
+		key := iterator.Key()
+		switch {
+		case key.Kind() == reflect.String:
+			name = key.String()
+		case key.Type().Implements(textmarshaler):
+			text, e := key.Interface().(encoding.TextMarshaler).MarshalText()
+			if e != nil {
+				return nil, e
+			}
+
+			name = string(text)
+		case key.CanInt() || key.CanUint():
+			name = fmt.Sprint(key.Interface())
+		default:
+			return nil, w.errorf(ErrUnsupportedKind, "map key %s", key.Kind())
+		}
+
+		w.path = append(w.path, name)
+
+		value, e := w.value(iterator.Value())
+
+		w.path = w.path[:len(w.path)-1]
+
+		if e != nil {
+			return nil, e
+		}
+
+		result[name] = value
+	}
+
+	return result, nil
+}
+
+// structure converts a struct's field(s). Anonymous embedded structs without an explicit tag name have their fields
+// promoted, with the outer struct's fields taking precedence.
+func (w *walker) structure(v reflect.Value) (map[string]interface{}, error) {
+	p := compile(v.Type(), w.options)
+
+	result := make(map[string]interface{}, len(p.fields))
+	promoted := make(map[string]interface{})
+
+	for _, field := range p.fields {
+		value := v.Field(field.index)
+
+		if field.embedded {
+			for value.Kind() == reflect.Pointer {
+				if value.IsNil() {
+					break
+				}
+
+				value = value.Elem()
+			}
+
+			if value.Kind() != reflect.Struct {
+				continue
+			}
+
+			fields, e := w.structure(value)
+			if e != nil {
+				return nil, e
+			}
+
+			for name, converted := range fields {
+				promoted[name] = converted
+			}
+
+			continue
+		}
+
+		if field.omitempty && empty(value) {
+			continue
+		}
+
+		if field.direct {
+			result[field.name] = value.Interface()
+			continue
+		}
+
+		w.path = append(w.path, field.name)
+
+		converted, e := w.value(value)
+
+		w.path = w.path[:len(w.path)-1]
+
+		if e != nil {
+			return nil, e
+		}
+
+		result[field.name] = converted
+	}
+
+	for name, value := range promoted {
+		if _, exists := result[name]; !(exists) {
+			result[name] = value
+		}
+	}
+
+	return result, nil
+}
+
+// embedded reports whether the field is an anonymous struct (or struct pointer) whose fields should be promoted.
+func embedded(structure reflect.StructField, o *Options) bool {
+	if !(structure.Anonymous) {
+		return false
+	}
+
+	typ := structure.Type
+	if typ.Kind() == reflect.Pointer {
+		// --> matching encoding/json, unexported embedded pointers can't be dereferenced safely
+		if !(structure.IsExported()) {
+			return false
+		}
+
+		typ = typ.Elem()
+	}
+
+	if typ.Kind() != reflect.Struct || leaf(typ) {
+		return false
+	}
+
+	for _, key := range o.Tags {
+		if tag, ok := structure.Tag.Lookup(key); ok {
+			name, flags, _ := strings.Cut(tag, ",")
+			if name == "-" && flags == "" {
+				return false
+			}
+
+			if name != "" {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// Convert is the error-returning variant of [Map]. The input must be a struct or a (non-nil) struct pointer; nested pointers,
+// interfaces, slices, arrays, maps and struct(s) are converted recursively. Chan, func and unsafe pointer values return
+// [ErrUnsupportedKind], and self-referencing value(s) return [ErrCycle].
+func Convert(obj interface{}, settings ...Variadic) (map[string]interface{}, error) {
+	o := options()
+	for _, option := range settings {
+		option(o)
+	}
+
+	w := &walker{options: o, visiting: make(map[visit]struct{})}
+
+	v := reflect.ValueOf(obj)
+	for v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return nil, ErrNotStruct
+		}
+
+		w.enter(v)
+
+		v = v.Elem()
+	}
+
+	if v.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("%w: %s", ErrNotStruct, v.Kind())
+	}
+
+	if cacheable(v.Type()) {
+		return nil, fmt.Errorf("%w: %s serializes itself", ErrNotStruct, v.Type())
+	}
+
+	return w.structure(v)
+}
@@ -0,0 +1,593 @@
+package reflection
+
+import (
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+var (
+	unmarshaler = reflect.TypeFor[encoding.TextUnmarshaler]()
+	timestamp   = reflect.TypeFor[time.Time]()
+	duration    = reflect.TypeFor[time.Duration]()
+	number      = reflect.TypeFor[json.Number]()
+)
+
+// layouts are the [time.Time] format(s) attempted, in order, when decoding a string into a timestamp.
+var layouts = []string{time.RFC3339Nano, time.DateTime, time.DateOnly}
+
+// FieldError describes a value that couldn't be coerced into its target field.
+type FieldError struct {
+	Field string       // Field is the dot-delimited path of the target field.
+	Value interface{}  // Value is the offending source value.
+	Type  reflect.Type // Type is the target field's type.
+	Err   error        // Err is the underlying parse or conversion error.
+}
+
+func (e *FieldError) Error() string {
+	return fmt.Sprintf("field %q: cannot decode %T (%v) into %s: %s", e.Field, e.Value, e.Value, e.Type, e.Err)
+}
+
+func (e *FieldError) Unwrap() error {
+	return e.Err
+}
+
+// DecodeError is returned by [Decode] and aggregates every problem found while decoding, rather than only the first.
+type DecodeError struct {
+	Unknown []string      // Unknown lists source key(s) that matched no target field.
+	Missing []string      // Missing lists required target field(s) absent from the source; see [Decode].
+	Invalid []*FieldError // Invalid lists value(s) that couldn't be coerced into their target field's type.
+}
+
+func (e *DecodeError) Error() string {
+	var partials []string
+	if len(e.Unknown) > 0 {
+		partials = append(partials, fmt.Sprintf("unknown field(s): %s", strings.Join(e.Unknown, ", ")))
+	}
+
+	if len(e.Missing) > 0 {
+		partials = append(partials, fmt.Sprintf("missing field(s): %s", strings.Join(e.Missing, ", ")))
+	}
+
+	for _, invalid := range e.Invalid {
+		partials = append(partials, invalid.Error())
+	}
+
+	return "decode: " + strings.Join(partials, "; ")
+}
+
+// Unwrap exposes the [FieldError] value(s) to [errors.Is] and [errors.As].
+func (e *DecodeError) Unwrap() []error {
+	errs := make([]error, len(e.Invalid))
+	for index := range e.Invalid {
+		errs[index] = e.Invalid[index]
+	}
+
+	return errs
+}
+
+func (e *DecodeError) empty() bool {
+	return len(e.Unknown) == 0 && len(e.Missing) == 0 && len(e.Invalid) == 0
+}
+
+// normalize folds a key or field name for tolerant matching - e.g. "Display-Name", "display_name" and "displayName"
+// all normalize to "displayname".
+func normalize(name string) string {
+	return strings.Map(func(r rune) rune {
+		switch r {
+		case '-', '_', ' ', '.':
+			return -1
+		}
+
+		return unicode.ToLower(r)
+	}, name)
+}
+
+// destination represents a decodable struct field.
+type destination struct {
+	name     string // name is the field's resolved map key; see [resolve].
+	index    []int  // index is the field's [reflect.Value.FieldByIndex] sequence, including promoted embedded field(s).
+	required bool   // required is true for non-pointer fields without "omitempty" reached without a pointer indirection.
+}
+
+// destinations returns the struct type's decodable field(s), keyed by their normalized name(s). Outer fields take precedence
+// over promoted ones, and a field's resolved name takes precedence over its go name.
+func destinations(typ reflect.Type, o *Options) (map[string]*destination, []*destination) {
+	var ordered []*destination
+
+	var collect func(typ reflect.Type, prefix []int, optional bool) []*destination
+	collect = func(typ reflect.Type, prefix []int, optional bool) []*destination {
+		var fields, promoted []*destination
+		for i := 0; i < typ.NumField(); i++ {
+			structure := typ.Field(i)
+			index := append(append([]int(nil), prefix...), i)
+
+			if embedded(structure, o) {
+				inner := structure.Type
+				if inner.Kind() == reflect.Pointer {
+					inner = inner.Elem()
+				}
+
+				promoted = append(promoted, collect(inner, index, optional || structure.Type.Kind() == reflect.Pointer)...)
+
+				continue
+			}
+
+			directive := resolve(structure, o)
+			if directive.skip {
+				continue
+			}
+
+			fields = append(fields, &destination{
+				name:     directive.name,
+				index:    index,
+				required: !(optional) && !(directive.omitempty) && structure.Type.Kind() != reflect.Pointer,
+			})
+		}
+
+		return append(fields, promoted...)
+	}
+
+	lookup := make(map[string]*destination)
+
+	for _, target := range collect(typ, nil, false) {
+		key := normalize(target.name)
+		if _, exists := lookup[key]; exists {
+			continue
+		}
+
+		lookup[key] = target
+		ordered = append(ordered, target)
+	}
+
+	// --> go field name(s) as alias(es), e.g. "DisplayName" for a field tagged `json:"avatar-name"`
+	for _, target := range ordered {
+		alias := normalize(typ.FieldByIndex(target.index).Name)
+		if _, exists := lookup[alias]; !(exists) {
+			lookup[alias] = target
+		}
+	}
+
+	return lookup, ordered
+}
+
+// decoder populates value(s) from generic map[string]interface{}, []interface{} and scalar source(s).
+type decoder struct {
+	options *Options
+	path    []string
+	result  *DecodeError
+	strict  bool // strict disables coercion between the string, bool and numeric kinds; see [mismatched].
+}
+
+func (d *decoder) field(name string) string {
+	return strings.Join(append(d.path, name), ".")
+}
+
+func (d *decoder) invalid(value interface{}, typ reflect.Type, e error) {
+	d.result.Invalid = append(d.result.Invalid, &FieldError{Field: strings.Join(d.path, "."), Value: value, Type: typ, Err: e})
+}
+
+// structure decodes the source map into the struct value.
+func (d *decoder) structure(source reflect.Value, v reflect.Value) {
+	p := compile(v.Type(), d.options)
+
+	found := make(map[*destination]struct{}, len(p.ordered))
+
+	iterator := source.MapRange()
+	for iterator.Next() {
+		key := fmt.Sprint(iterator.Key().Interface())
+
+		target, ok := p.lookup[normalize(key)]
+		if !(ok) {
+			d.result.Unknown = append(d.result.Unknown, d.field(key))
+			continue
+		}
+
+		found[target] = struct{}{}
+
+		d.path = append(d.path, target.name)
+
+		d.value(iterator.Value().Interface(), traverse(v, target.index))
+
+		d.path = d.path[:len(d.path)-1]
+	}
+
+	for _, target := range p.ordered {
+		if _, ok := found[target]; !(ok) && target.required {
+			d.result.Missing = append(d.result.Missing, d.field(target.name))
+		}
+	}
+}
+
+// traverse returns the field at index, allocating nil embedded struct pointer(s) along the way.
+func traverse(v reflect.Value, index []int) reflect.Value {
+	for position, i := range index {
+		if position > 0 && v.Kind() == reflect.Pointer {
+			if v.IsNil() {
+				v.Set(reflect.New(v.Type().Elem()))
+			}
+
+			v = v.Elem()
+		}
+
+		v = v.Field(i)
+	}
+
+	return v
+}
+
+// value coerces the source into v, recording any failure against the current path.
+func (d *decoder) value(source interface{}, v reflect.Value) {
+	if source == nil {
+		v.Set(reflect.Zero(v.Type()))
+		return
+	}
+
+	typ := v.Type()
+
+	if typ.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			v.Set(reflect.New(typ.Elem()))
+		}
+
+		d.value(source, v.Elem())
+
+		return
+	}
+
+	s := reflect.ValueOf(source)
+
+	switch {
+	case typ == timestamp:
+		if t, e := instant(source); e != nil {
+			d.invalid(source, typ, e)
+		} else {
+			v.Set(reflect.ValueOf(t))
+		}
+
+		return
+	case typ == duration && s.Kind() == reflect.String:
+		if value, e := time.ParseDuration(s.String()); e != nil {
+			d.invalid(source, typ, e)
+		} else {
+			v.SetInt(int64(value))
+		}
+
+		return
+	case s.Kind() == reflect.String && reflect.PointerTo(typ).Implements(unmarshaler):
+		if e := v.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(s.String())); e != nil {
+			d.invalid(source, typ, e)
+		}
+
+		return
+	case s.Type().AssignableTo(typ):
+		v.Set(s)
+		return
+	case d.strict && mismatched(s, typ):
+		d.invalid(source, typ, fmt.Errorf("%w: %s into %s", ErrTypeMismatch, s.Type(), typ))
+		return
+	}
+
+	switch typ.Kind() {
+	case reflect.Interface:
+		d.invalid(source, typ, fmt.Errorf("%s doesn't implement %s", s.Type(), typ))
+	case reflect.String:
+		switch s.Kind() {
+		case reflect.String:
+			v.SetString(s.String())
+		case reflect.Slice:
+			if s.Type().Elem().Kind() != reflect.Uint8 {
+				d.invalid(source, typ, ErrUnsupportedKind)
+				return
+			}
+
+			v.SetString(string(s.Bytes()))
+		case reflect.Bool, reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+			reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Float32, reflect.Float64:
+			v.SetString(fmt.Sprint(source))
+		default:
+			d.invalid(source, typ, ErrUnsupportedKind)
+		}
+	case reflect.Bool:
+		switch s.Kind() {
+		case reflect.Bool:
+			v.SetBool(s.Bool())
+		case reflect.String:
+			value, e := strconv.ParseBool(strings.TrimSpace(s.String()))
+			if e != nil {
+				d.invalid(source, typ, e)
+				return
+			}
+
+			v.SetBool(value)
+		default:
+			d.invalid(source, typ, ErrUnsupportedKind)
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		var value int64
+		switch {
+		case s.CanInt():
+			value = s.Int()
+		case s.CanUint():
+			if s.Uint() > math.MaxInt64 {
+				d.invalid(source, typ, strconv.ErrRange)
+				return
+			}
+
+			value = int64(s.Uint())
+		case s.CanFloat():
+			if s.Float() != math.Trunc(s.Float()) {
+				d.invalid(source, typ, fmt.Errorf("%v is not an integer", s.Float()))
+				return
+			}
+
+			value = int64(s.Float())
+		case s.Kind() == reflect.String:
+			parsed, e := strconv.ParseInt(strings.TrimSpace(s.String()), 10, typ.Bits())
+			if e != nil {
+				d.invalid(source, typ, e)
+				return
+			}
+
+			value = parsed
+		default:
+			d.invalid(source, typ, ErrUnsupportedKind)
+			return
+		}
+
+		if v.OverflowInt(value) {
+			d.invalid(source, typ, strconv.ErrRange)
+			return
+		}
+
+		v.SetInt(value)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		var value uint64
+		switch {
+		case s.CanUint():
+			value = s.Uint()
+		case s.CanInt():
+			if s.Int() < 0 {
+				d.invalid(source, typ, strconv.ErrRange)
+				return
+			}
+
+			value = uint64(s.Int())
+		case s.CanFloat():
+			if s.Float() < 0 || s.Float() != math.Trunc(s.Float()) {
+				d.invalid(source, typ, fmt.Errorf("%v is not an unsigned integer", s.Float()))
+				return
+			}
+
+			value = uint64(s.Float())
+		case s.Kind() == reflect.String:
+			parsed, e := strconv.ParseUint(strings.TrimSpace(s.String()), 10, typ.Bits())
+			if e != nil {
+				d.invalid(source, typ, e)
+				return
+			}
+
+			value = parsed
+		default:
+			d.invalid(source, typ, ErrUnsupportedKind)
+			return
+		}
+
+		if v.OverflowUint(value) {
+			d.invalid(source, typ, strconv.ErrRange)
+			return
+		}
+
+		v.SetUint(value)
+	case reflect.Float32, reflect.Float64:
+		var value float64
+		switch {
+		case s.CanFloat():
+			value = s.Float()
+		case s.CanInt():
+			value = float64(s.Int())
+		case s.CanUint():
+			value = float64(s.Uint())
+		case s.Kind() == reflect.String:
+			parsed, e := strconv.ParseFloat(strings.TrimSpace(s.String()), typ.Bits())
+			if e != nil {
+				d.invalid(source, typ, e)
+				return
+			}
+
+			value = parsed
+		default:
+			d.invalid(source, typ, ErrUnsupportedKind)
+			return
+		}
+
+		if v.OverflowFloat(value) {
+			d.invalid(source, typ, strconv.ErrRange)
+			return
+		}
+
+		v.SetFloat(value)
+	case reflect.Slice:
+		if typ.Elem().Kind() == reflect.Uint8 && s.Kind() == reflect.String {
+			v.SetBytes([]byte(s.String()))
+			return
+		}
+
+		// --> e.g. a query string's "a,b,c"
+		if s.Kind() == reflect.String {
+			partials := strings.Split(s.String(), ",")
+
+			elements := make([]interface{}, len(partials))
+			for index := range partials {
+				elements[index] = strings.TrimSpace(partials[index])
+			}
+
+			s = reflect.ValueOf(elements)
+		}
+
+		if s.Kind() != reflect.Slice && s.Kind() != reflect.Array {
+			d.invalid(source, typ, ErrUnsupportedKind)
+			return
+		}
+
+		slice := reflect.MakeSlice(typ, s.Len(), s.Len())
+		d.sequence(s, slice)
+
+		v.Set(slice)
+	case reflect.Array:
+		if (s.Kind() != reflect.Slice && s.Kind() != reflect.Array) || s.Len() != typ.Len() {
+			d.invalid(source, typ, fmt.Errorf("expected a sequence of length %d", typ.Len()))
+			return
+		}
+
+		d.sequence(s, v)
+	case reflect.Map:
+		if s.Kind() != reflect.Map {
+			d.invalid(source, typ, ErrUnsupportedKind)
+			return
+		}
+
+		if typ.Key().Kind() != reflect.String {
+			d.invalid(source, typ, fmt.Errorf("%w: map key %s", ErrUnsupportedKind, typ.Key().Kind()))
+			return
+		}
+
+		mapping := reflect.MakeMapWithSize(typ, s.Len())
+
+		iterator := s.MapRange()
+		for iterator.Next() {
+			key := fmt.Sprint(iterator.Key().Interface())
+
+			element := reflect.New(typ.Elem()).Elem()
+
+			d.path = append(d.path, key)
+			d.value(iterator.Value().Interface(), element)
+			d.path = d.path[:len(d.path)-1]
+
+			mapping.SetMapIndex(reflect.ValueOf(key).Convert(typ.Key()), element)
+		}
+
+		v.Set(mapping)
+	case reflect.Struct:
+		if s.Kind() != reflect.Map {
+			d.invalid(source, typ, ErrUnsupportedKind)
+			return
+		}
+
+		d.structure(s, v)
+	default:
+		d.invalid(source, typ, ErrUnsupportedKind)
+	}
+}
+
+// family groups the kind(s) a strict [decoder] considers interchangeable: 1 for strings, 2 for bools, 3 for numbers, and 0
+// for everything else.
+func family(kind reflect.Kind) int {
+	switch kind {
+	case reflect.String:
+		return 1
+	case reflect.Bool:
+		return 2
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64:
+		return 3
+	}
+
+	return 0
+}
+
+// mismatched reports whether a strict [decoder] must reject the source for the target type - e.g. a number for a string
+// field, or a string for a numeric, bool or (comma-delimited) slice field. A [json.Number] is treated as numeric.
+func mismatched(s reflect.Value, typ reflect.Type) bool {
+	source, target := family(s.Kind()), family(typ.Kind())
+	if s.Type() == number {
+		source = 3
+	}
+
+	if typ.Kind() == reflect.Slice && typ.Elem().Kind() != reflect.Uint8 {
+		return source != 0
+	}
+
+	return source != 0 && target != 0 && source != target
+}
+
+// sequence decodes each element of the source slice or array into the target's corresponding index.
+func (d *decoder) sequence(source reflect.Value, v reflect.Value) {
+	for i := 0; i < source.Len(); i++ {
+		d.path = append(d.path, fmt.Sprintf("[%d]", i))
+		d.value(source.Index(i).Interface(), v.Index(i))
+		d.path = d.path[:len(d.path)-1]
+	}
+}
+
+// instant coerces a string (see layouts), unix-seconds numeric, or [time.Time] source into a timestamp.
+func instant(source interface{}) (time.Time, error) {
+	switch value := source.(type) {
+	case time.Time:
+		return value, nil
+	case string:
+		value = strings.TrimSpace(value)
+
+		for _, layout := range layouts {
+			if t, e := time.Parse(layout, value); e == nil {
+				return t, nil
+			}
+		}
+
+		if seconds, e := strconv.ParseInt(value, 10, 64); e == nil {
+			return time.Unix(seconds, 0).UTC(), nil
+		}
+
+		return time.Time{}, fmt.Errorf("unrecognized timestamp format %q", value)
+	case int, int32, int64, uint, uint32, uint64, float64:
+		seconds, _ := strconv.ParseFloat(fmt.Sprint(value), 64)
+
+		return time.Unix(0, int64(seconds*float64(time.Second))).UTC(), nil
+	}
+
+	return time.Time{}, fmt.Errorf("%w: %T", ErrUnsupportedKind, source)
+}
+
+// Decode is the inverse of [Map]: it populates the struct pointed to by target from the source map, using the same [Options]
+// naming rule(s). Keys are matched case-insensitively, ignoring "-", "_", "." and space separators, against each field's resolved
+// name and its go name - so "display-name", "display_name", "DisplayName" and "displayName" are all equivalent.
+//
+// String source value(s) are coerced into numbers, bools, [time.Duration], [time.Time] (RFC 3339, [time.DateTime],
+// [time.DateOnly] or unix seconds), [encoding.TextUnmarshaler] implementations, and comma-delimited slices. Nested maps
+// decode into nested struct(s) and maps.
+//
+// Every problem is collected into a single [DecodeError]: source keys without a matching field, required fields absent from the
+// source, and value(s) that couldn't be coerced. A field is required unless it's a pointer, tagged "omitempty", or promoted
+// through an embedded struct pointer. Valid fields are populated regardless of the error.
+func Decode(source map[string]interface{}, target interface{}, settings ...Variadic) error {
+	o := options()
+	for _, option := range settings {
+		option(o)
+	}
+
+	v := reflect.ValueOf(target)
+	if v.Kind() != reflect.Pointer || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("%w: decode target must be a non-nil struct pointer, received %T", ErrNotStruct, target)
+	}
+
+	d := &decoder{options: o, result: &DecodeError{}}
+
+	d.structure(reflect.ValueOf(source), v.Elem())
+
+	if d.result.empty() {
+		return nil
+	}
+
+	sort.Strings(d.result.Unknown)
+	sort.Strings(d.result.Missing)
+
+	return d.result
+}
@@ -4,13 +4,17 @@ import (
 	"reflect"
 	"strings"
 
-	"user-service/internal/strcase"
+	"library/strcase"
 )
 
 // Options is the configuration structure optionally mutated via the [Variadic] constructor used throughout the package.
 type Options struct {
-	Lowercase bool // Lowercase forces lowercase on structure's fields. Defaults to true.
-	Kebab     bool // Kebab will cast fields to kebab-style-casing. Defaults to true.
+	// Naming names fields without an applicable tag. Defaults to [Kebab]; a nil strategy is equivalent to [Preserve].
+	Naming NamingStrategy
+
+	// Tags is the priority-ordered list of struct tag keys consulted for a field's name (e.g. `json:"display-name"`). The first
+	// tag with a non-empty name wins; a "-" name excludes the field. Defaults to "json", then "db".
+	Tags []string
 }
 
 // Variadic represents a functional constructor for the [Options] type. Typical callers of Variadic won't need to perform
@@ -20,57 +24,130 @@ type Variadic func(o *Options)
 // options represents a default constructor.
 func options() *Options {
 	return &Options{
-		Lowercase: true,
-		Kebab:     true,
+		Naming: Kebab,
+		Tags:   []string{"json", "db"},
 	}
 }
 
-func Map(obj interface{}, settings ...Variadic) map[string]interface{} {
-	o := options()
-	for _, option := range settings {
-		option(o)
+// Tags sets [Options.Tags] - e.g. Tags("db", "json") to prefer database column names.
+func Tags(tags ...string) Variadic {
+	return func(o *Options) {
+		o.Tags = tags
 	}
+}
 
-	// 1. Create an empty map named result to store the fields and their values.
-	result := make(map[string]interface{})
+// Naming sets [Options.Naming] - e.g. Naming(Snake), or Naming(strings.ToUpper) for a custom strategy.
+func Naming(strategy NamingStrategy) Variadic {
+	return func(o *Options) {
+		o.Naming = strategy
+	}
+}
 
-	// 2. Get the reflect.Value and reflect.Type of the input object.
-	val := reflect.ValueOf(obj)
+// converter backs the package's naming strategies, isolated from process-wide strcase configuration (e.g. [strcase.ConfigureAcronym]).
+var converter = strcase.NewBuilder().Build()
 
-	// 3. If the input object is a pointer, dereference it to get the underlying value.
-	if val.Kind() == reflect.Ptr {
-		val = val.Elem()
+// NamingStrategy converts a go field name (e.g. "DisplayName") into a map key. Any func(string) string can serve as a custom
+// strategy; the package provides [Kebab], [Snake], [Camel], [LowerCamel], [ScreamingSnake] and [Preserve].
+type NamingStrategy func(name string) string
+
+// Kebab names fields in kebab-case, e.g. "DisplayName" -> "display-name".
+func Kebab(name string) string {
+	return converter.ToKebab(name)
+}
+
+// Snake names fields in snake_case, e.g. "DisplayName" -> "display_name".
+func Snake(name string) string {
+	return converter.ToSnake(name)
+}
+
+// Camel names fields in CamelCase, e.g. "display_name" -> "DisplayName".
+func Camel(name string) string {
+	return converter.ToCamel(name)
+}
+
+// LowerCamel names fields in lowerCamelCase, e.g. "DisplayName" -> "displayName".
+func LowerCamel(name string) string {
+	return converter.ToLowerCamel(name)
+}
+
+// ScreamingSnake names fields in SCREAMING_SNAKE_CASE, e.g. "DisplayName" -> "DISPLAY_NAME".
+func ScreamingSnake(name string) string {
+	return converter.ToScreamingSnake(name)
+}
+
+// Preserve keeps the go field name as-is, e.g. "DisplayName" -> "DisplayName".
+func Preserve(name string) string {
+	return name
+}
+
+// field represents a struct field's resolved naming directive(s).
+type field struct {
+	name      string
+	skip      bool // skip is true for unexported fields, or fields tagged with "-".
+	omitempty bool // omitempty is true when the applied tag includes the "omitempty" option.
+}
+
+// resolve determines a struct field's map key according to the [Options].
+func resolve(structure reflect.StructField, o *Options) field {
+	if !(structure.IsExported()) {
+		return field{skip: true}
 	}
 
-	typ := val.Type()
+	omitempty := false
+	for _, key := range o.Tags {
+		tag, ok := structure.Tag.Lookup(key)
+		if !(ok) {
+			continue
+		}
 
-	// 4. Iterate through the fields of the struct using a for loop.
-	for i := 0; i < val.NumField(); i++ {
-		// 5. For each field, get its name and kind (e.g., string, int, struct).
-		field := typ.Field(i).Name
-		if o.Kebab {
-			field = strcase.ToKebab(field)
+		name, flags, _ := strings.Cut(tag, ",")
+		if name == "-" && flags == "" {
+			return field{skip: true}
 		}
 
-		if !o.Lowercase {
-			field = strings.ToLower(field)
+		for _, flag := range strings.Split(flags, ",") {
+			omitempty = omitempty || flag == "omitempty"
 		}
 
-		// field value's kind
-		kind := val.Field(i).Kind()
+		// --> e.g. `json:",omitempty"` - keep the flag(s), but derive the name from the next tag or convention
+		if name != "" {
+			return field{name: name, omitempty: omitempty}
+		}
+	}
 
-		var value interface{}
+	return field{name: convention(structure.Name, o), omitempty: omitempty}
+}
 
-		// 6. If the field is a struct, recursively call structToMap to get the map representation of the nested struct.
-		// Otherwise, get the field value directly.
-		if kind == reflect.Struct {
-			value = Map(val.Field(i).Interface())
-		} else {
-			value = val.Field(i).Interface()
-		}
+// empty reports whether the value is considered empty for "omitempty" purposes, mirroring [encoding/json].
+func empty(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		return v.Len() == 0
+	case reflect.Bool, reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64, reflect.Interface, reflect.Pointer:
+		return v.IsZero()
+	}
+
+	return false
+}
+
+// convention applies the [Options] naming strategy to a go field name.
+func convention(name string, o *Options) string {
+	if o.Naming == nil {
+		return name
+	}
+
+	return o.Naming(name)
+}
 
-		// 7. Add the field name and value to the result map.
-		result[field] = value
+// Map converts the struct (or struct pointer) into a map keyed by each field's tag name - or, absent a tag, its
+// [Options] naming strategy. Nested value(s) are converted recursively; see [Convert] for the complete rule set.
+// Map returns nil if the input can't be converted - use [Convert] to inspect the error.
+func Map(obj interface{}, settings ...Variadic) map[string]interface{} {
+	result, e := Convert(obj, settings...)
+	if e != nil {
+		return nil
 	}
 
 	return result
@@ -0,0 +1,579 @@
+package reflection
+
+import (
+	"errors"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"library/strcase"
+)
+
+type helper struct{}
+
+type profile struct {
+	helper      `json:"-"`
+	ID          int64   `db:"id" json:"id"`
+	DisplayName *string `db:"display-name" json:"display-name"`
+	Username    string  `db:"username" json:"username,omitempty"`
+	Marketing   bool    `db:"marketing-opt-in"`
+	AccountType string
+	Ignored     string `json:"-"`
+	internal    string
+}
+
+func TestMapTags(t *testing.T) {
+	name := "Example"
+	input := profile{ID: 1, DisplayName: &name, Marketing: true, AccountType: "ROOT", Ignored: "x", internal: "y"}
+
+	tests := []struct {
+		name     string
+		settings []Variadic
+		expected map[string]interface{}
+	}{
+		{
+			name:     "Default",
+			expected: map[string]interface{}{"id": int64(1), "display-name": name, "marketing-opt-in": true, "account-type": "ROOT"},
+		},
+		{
+			name:     "Naming",
+			settings: []Variadic{Tags("json"), Naming(strings.ToUpper)},
+			expected: map[string]interface{}{"id": int64(1), "display-name": name, "MARKETING": true, "ACCOUNTTYPE": "ROOT"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if result := Map(input, test.settings...); !(reflect.DeepEqual(result, test.expected)) {
+				t.Errorf("expected %v, got %v", test.expected, result)
+			}
+		})
+	}
+}
+
+func TestMapTagPriority(t *testing.T) {
+	type row struct {
+		Email string `db:"email-address" json:"email,omitempty"`
+	}
+
+	if result := Map(row{Email: "user@example.com"}, Tags("db", "json")); result["email-address"] != "user@example.com" {
+		t.Errorf("expected db tag to take priority, got %v", result)
+	}
+
+	if result := Map(row{}); len(result) != 0 {
+		t.Errorf("expected omitempty to exclude the zero-value field, got %v", result)
+	}
+}
+
+type node struct {
+	Name     string  `json:"name"`
+	Next     *node   `json:"next"`
+	Children []*node `json:"children,omitempty"`
+}
+
+type audit struct {
+	Creator string `json:"creator"`
+	Version int    `json:"version"`
+}
+
+type document struct {
+	audit
+	*node // --> matching encoding/json, unexported embedded pointers are ignored
+
+	Version  string                     `json:"version"`
+	Avatar   *string                    `json:"avatar"`
+	Tags     map[string][]audit         `json:"tags"`
+	Callback func()                     `json:"-"`
+	Nested   map[int]map[string]*string `json:"nested,omitempty"`
+}
+
+func TestConvert(t *testing.T) {
+	avatar := "https://example.com/avatar.png"
+
+	input := &document{
+		audit:   audit{Creator: "root", Version: 1},
+		node:    &node{Name: "leaf"},
+		Version: "v2",
+		Avatar:  &avatar,
+		Tags:    map[string][]audit{"history": {{Creator: "member", Version: 0}}},
+	}
+
+	expected := map[string]interface{}{
+		"creator": "root",
+		"version": "v2",
+		"avatar":  avatar,
+		"tags":    map[string]interface{}{"history": []interface{}{map[string]interface{}{"creator": "member", "version": 0}}},
+	}
+
+	result, e := Convert(input)
+	if e != nil {
+		t.Fatalf("unexpected error: %v", e)
+	}
+
+	if !(reflect.DeepEqual(result, expected)) {
+		t.Errorf("expected %v, got %v", expected, result)
+	}
+}
+
+func TestConvertErrors(t *testing.T) {
+	cycle := &node{Name: "a"}
+	cycle.Next = &node{Name: "b", Next: cycle}
+
+	type unsupported struct {
+		Channel chan int
+	}
+
+	tests := []struct {
+		name     string
+		input    interface{}
+		expected error
+	}{
+		{"Cycle", cycle, ErrCycle},
+		{"Unsupported", unsupported{Channel: make(chan int)}, ErrUnsupportedKind},
+		{"Scalar", 1, ErrNotStruct},
+		{"Nil", (*node)(nil), ErrNotStruct},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if _, e := Convert(test.input); !(errors.Is(e, test.expected)) {
+				t.Errorf("expected %v, got %v", test.expected, e)
+			}
+
+			if result := Map(test.input); result != nil {
+				t.Errorf("expected a nil map, got %v", result)
+			}
+		})
+	}
+}
+
+func TestConvertSharedReference(t *testing.T) {
+	shared := &node{Name: "shared"}
+
+	// --> the same pointer appearing twice (but not on the same path) isn't a cycle
+	if _, e := Convert(&node{Name: "root", Children: []*node{shared, shared}}); e != nil {
+		t.Errorf("unexpected error: %v", e)
+	}
+}
+
+type settings struct {
+	Name     string        `json:"name"`
+	Port     uint16        `json:"port"`
+	Ratio    float64       `json:"ratio,omitempty"`
+	Enabled  bool          `json:"enabled"`
+	Timeout  time.Duration `json:"timeout"`
+	Creation time.Time     `json:"creation"`
+	Hosts    []string      `json:"hosts"`
+	Labels   map[string]int
+	Owner    *audit `json:"owner"`
+	Parent   *string
+}
+
+func TestDecode(t *testing.T) {
+	source := map[string]interface{}{
+		"NAME":     "playground",
+		"port":     "8080",
+		"Enabled":  "true",
+		"timeout":  "1m30s",
+		"creation": "2024-06-01T12:00:00Z",
+		"hosts":    "a.example.com, b.example.com",
+		"labels":   map[string]interface{}{"replicas": "3"},
+		"owner":    map[string]interface{}{"Creator": "root", "version": 2.0},
+	}
+
+	var result settings
+	if e := Decode(source, &result); e != nil {
+		t.Fatalf("unexpected error: %v", e)
+	}
+
+	expected := settings{
+		Name:     "playground",
+		Port:     8080,
+		Enabled:  true,
+		Timeout:  90 * time.Second,
+		Creation: time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC),
+		Hosts:    []string{"a.example.com", "b.example.com"},
+		Labels:   map[string]int{"replicas": 3},
+		Owner:    &audit{Creator: "root", Version: 2},
+	}
+
+	if !(reflect.DeepEqual(result, expected)) {
+		t.Errorf("expected %+v, got %+v", expected, result)
+	}
+}
+
+func TestDecodeKeyMatching(t *testing.T) {
+	for _, key := range []string{"display-name", "display_name", "DisplayName", "displayName", "Display Name"} {
+		t.Run(key, func(t *testing.T) {
+			var result profile
+			if e := Decode(map[string]interface{}{key: "Example"}, &result); e != nil {
+				var decode *DecodeError
+				if !(errors.As(e, &decode)) || len(decode.Unknown) > 0 {
+					t.Fatalf("unexpected error: %v", e)
+				}
+			}
+
+			if result.DisplayName == nil || *result.DisplayName != "Example" {
+				t.Errorf("expected display name to be decoded, got %v", result.DisplayName)
+			}
+		})
+	}
+}
+
+func TestDecodeError(t *testing.T) {
+	source := map[string]interface{}{
+		"port":    "99999",
+		"enabled": "maybe",
+		"unknown": 1,
+		"owner":   map[string]interface{}{"creator": "root", "extra": true},
+	}
+
+	var result settings
+
+	var decode *DecodeError
+	if e := Decode(source, &result); !(errors.As(e, &decode)) {
+		t.Fatalf("expected a DecodeError, got %v", e)
+	}
+
+	if expected := []string{"owner.extra", "unknown"}; !(reflect.DeepEqual(decode.Unknown, expected)) {
+		t.Errorf("expected unknown %v, got %v", expected, decode.Unknown)
+	}
+
+	if expected := []string{"creation", "hosts", "labels", "name", "owner.version", "timeout"}; !(reflect.DeepEqual(decode.Missing, expected)) {
+		t.Errorf("expected missing %v, got %v", expected, decode.Missing)
+	}
+
+	if len(decode.Invalid) != 2 {
+		t.Errorf("expected 2 invalid fields, got %v", decode.Invalid)
+	}
+
+	if !(errors.Is(decode, strconv.ErrRange)) {
+		t.Errorf("expected the port overflow to unwrap to %v", strconv.ErrRange)
+	}
+
+	if result.Owner == nil || result.Owner.Creator != "root" {
+		t.Errorf("expected valid fields to be populated, got %+v", result.Owner)
+	}
+}
+
+func TestDecodeTarget(t *testing.T) {
+	var result settings
+	if e := Decode(nil, result); !(errors.Is(e, ErrNotStruct)) {
+		t.Errorf("expected %v, got %v", ErrNotStruct, e)
+	}
+}
+
+func TestDecodeRoundTrip(t *testing.T) {
+	name := "Example"
+	input := profile{ID: 1, DisplayName: &name, Username: "example", Marketing: true, AccountType: "ROOT"}
+
+	var result profile
+	if e := Decode(Map(input), &result); e != nil {
+		t.Fatalf("unexpected error: %v", e)
+	}
+
+	if !(reflect.DeepEqual(result, input)) {
+		t.Errorf("expected %+v, got %+v", input, result)
+	}
+}
+
+func TestPlanCache(t *testing.T) {
+	input := settings{Name: "playground", Hosts: []string{"a.example.com"}}
+
+	var group sync.WaitGroup
+	for i := 0; i < 16; i++ {
+		group.Add(1)
+		go func() {
+			defer group.Done()
+
+			if result := Map(input); result["name"] != "playground" {
+				t.Errorf("unexpected result: %v", result)
+			}
+		}()
+	}
+
+	group.Wait()
+
+	// --> differing options must not share a plan
+	if result := Map(input, Naming(strings.ToUpper)); result["NAME"] != nil || result["name"] != "playground" {
+		t.Errorf("expected the json tag to take priority, got %v", result)
+	}
+
+	if result := Map(input, Tags(), Naming(strings.ToUpper)); result["NAME"] != "playground" {
+		t.Errorf("expected a distinct plan for differing options, got %v", result)
+	}
+}
+
+// reset evicts all cached plan(s), forcing the next call to recompute them.
+func reset() {
+	plans.Range(func(key, _ interface{}) bool {
+		plans.Delete(key)
+		return true
+	})
+}
+
+func benchmarkMap(b *testing.B, cached bool) {
+	name := "Example"
+	input := profile{ID: 1, DisplayName: &name, Username: "example", Marketing: true, AccountType: "ROOT"}
+
+	b.ReportAllocs()
+
+	for n := 0; n < b.N; n++ {
+		if !(cached) {
+			reset()
+		}
+
+		Map(input)
+	}
+}
+
+func BenchmarkMap(b *testing.B) {
+	benchmarkMap(b, true)
+}
+
+func BenchmarkMapUncached(b *testing.B) {
+	benchmarkMap(b, false)
+}
+
+func benchmarkDecode(b *testing.B, cached bool) {
+	source := map[string]interface{}{"id": "1", "display-name": "Example", "username": "example", "marketing-opt-in": "true", "account-type": "ROOT"}
+
+	b.ReportAllocs()
+
+	for n := 0; n < b.N; n++ {
+		if !(cached) {
+			reset()
+		}
+
+		var result profile
+		if e := Decode(source, &result); e != nil {
+			b.Fatal(e)
+		}
+	}
+}
+
+func BenchmarkDecode(b *testing.B) {
+	benchmarkDecode(b, true)
+}
+
+func BenchmarkDecodeUncached(b *testing.B) {
+	benchmarkDecode(b, false)
+}
+
+func TestNamingStrategy(t *testing.T) {
+	type account struct {
+		DisplayName string
+		AccountType string `json:"type"`
+	}
+
+	input := account{DisplayName: "Example", AccountType: "ROOT"}
+
+	tests := []struct {
+		name     string
+		strategy NamingStrategy
+		expected string
+	}{
+		{"Kebab", Kebab, "display-name"},
+		{"Snake", Snake, "display_name"},
+		{"Camel", Camel, "DisplayName"},
+		{"Lower-Camel", LowerCamel, "displayName"},
+		{"Screaming-Snake", ScreamingSnake, "DISPLAY_NAME"},
+		{"Preserve", Preserve, "DisplayName"},
+		{"Nil", nil, "DisplayName"},
+		{"Custom", func(name string) string { return "x-" + strings.ToLower(name) }, "x-displayname"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			expected := map[string]interface{}{test.expected: "Example", "type": "ROOT"}
+			if result := Map(input, Naming(test.strategy)); !(reflect.DeepEqual(result, expected)) {
+				t.Errorf("expected %v, got %v", expected, result)
+			}
+		})
+	}
+
+	if result := Map(input); result["display-name"] != "Example" {
+		t.Errorf("expected kebab-case by default, got %v", result)
+	}
+}
+
+func TestNamingStrategyIdentity(t *testing.T) {
+	type account struct {
+		UserID int64
+	}
+
+	input := account{UserID: 1}
+
+	// --> method values on distinct receivers share a code pointer; neither may reuse the other's cached plan
+	standard, loose := strcase.NewBuilder().Build(), strcase.NewBuilder().Without("ID").Build()
+
+	prefix := func(value string) NamingStrategy {
+		return func(name string) string { return value + name }
+	}
+
+	tests := []struct {
+		name     string
+		strategy NamingStrategy
+		expected string
+	}{
+		{"Method-Value", standard.ToCamel, "UserID"},
+		{"Method-Value-Without", loose.ToCamel, "UserId"},
+		{"Closure", prefix("x-"), "x-UserID"},
+		{"Closure-Captured", prefix("y-"), "y-UserID"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			for range 2 {
+				expected := map[string]interface{}{test.expected: int64(1)}
+				if result := Map(input, Naming(test.strategy)); !(reflect.DeepEqual(result, expected)) {
+					t.Errorf("expected %v, got %v", expected, result)
+				}
+
+				var decoded account
+				if e := Decode(map[string]interface{}{test.expected: 2}, &decoded, Naming(test.strategy)); e != nil || decoded.UserID != 2 {
+					t.Errorf("expected %q to decode, got %+v (%v)", test.expected, decoded, e)
+				}
+			}
+		})
+	}
+}
+
+func TestDiff(t *testing.T) {
+	before := settings{Name: "playground", Port: 8080, Hosts: []string{"a"}, Labels: map[string]int{"replicas": 1}, Owner: &audit{Creator: "root"}}
+	after := settings{Name: "playground", Port: 8443, Hosts: []string{"a", "b"}, Labels: map[string]int{"replicas": 1, "zone": 2}, Owner: &audit{Creator: "member"}}
+
+	changes, e := Diff(before, after)
+	if e != nil {
+		t.Fatalf("unexpected error: %v", e)
+	}
+
+	expected := []Change{
+		{Path: "hosts", Old: []interface{}{"a"}, New: []interface{}{"a", "b"}},
+		{Path: "labels.zone", Old: nil, New: 2},
+		{Path: "owner.creator", Old: "root", New: "member"},
+		{Path: "port", Old: uint16(8080), New: uint16(8443)},
+	}
+
+	if !(reflect.DeepEqual(changes, expected)) {
+		t.Errorf("expected %v, got %v", expected, changes)
+	}
+
+	if changes, _ := Diff(before, before, Naming(Snake)); len(changes) != 0 {
+		t.Errorf("expected no changes, got %v", changes)
+	}
+
+	if _, e := Diff(before, &after); !(errors.Is(e, ErrTypeMismatch)) {
+		t.Errorf("expected %v, got %v", ErrTypeMismatch, e)
+	}
+}
+
+func TestApply(t *testing.T) {
+	owner := &audit{Creator: "root", Version: 1}
+	labels := map[string]int{"replicas": 1, "zone": 2}
+
+	target := settings{Name: "playground", Port: 8080, Labels: labels, Owner: owner, Hosts: []string{"a"}}
+
+	patch := map[string]interface{}{
+		"port":   float64(8443),
+		"hosts":  nil,
+		"labels": map[string]interface{}{"zone": nil, "replicas": float64(3)},
+		"owner":  map[string]interface{}{"version": 2},
+	}
+
+	if e := Apply(&target, patch); e != nil {
+		t.Fatalf("unexpected error: %v", e)
+	}
+
+	expected := settings{Name: "playground", Port: 8443, Labels: map[string]int{"replicas": 3}, Owner: &audit{Creator: "root", Version: 2}}
+	if !(reflect.DeepEqual(target, expected)) {
+		t.Errorf("expected %+v, got %+v", expected, target)
+	}
+
+	// --> the original pointer(s) and map(s) must not be written through
+	if owner.Version != 1 || len(labels) != 2 {
+		t.Errorf("expected shared references to be unmodified, got %+v and %v", owner, labels)
+	}
+}
+
+func TestApplyStrict(t *testing.T) {
+	type address struct {
+		City string `json:"city"`
+	}
+
+	type contact struct {
+		Address address  `json:"addr"`
+		Port    int      `json:"port"`
+		Ratio   float64  `json:"ratio"`
+		Enabled bool     `json:"enabled"`
+		Tags    []string `json:"tags"`
+	}
+
+	tests := []struct {
+		name  string
+		patch map[string]interface{}
+		field string
+	}{
+		{"Number-String", map[string]interface{}{"addr": map[string]interface{}{"city": float64(1)}}, "addr.city"},
+		{"Bool-String", map[string]interface{}{"addr": map[string]interface{}{"city": true}}, "addr.city"},
+		{"String-Number", map[string]interface{}{"port": "8080"}, "port"},
+		{"String-Bool", map[string]interface{}{"enabled": "true"}, "enabled"},
+		{"Number-Bool", map[string]interface{}{"enabled": float64(1)}, "enabled"},
+		{"String-Slice", map[string]interface{}{"tags": "a,b"}, "tags"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			target := contact{Address: address{City: "Toronto"}, Port: 80}
+
+			var decode *DecodeError
+			if e := Apply(&target, test.patch); !(errors.As(e, &decode)) {
+				t.Fatalf("expected a DecodeError, got %v", e)
+			}
+
+			if len(decode.Invalid) != 1 || decode.Invalid[0].Field != test.field || !(errors.Is(decode.Invalid[0].Err, ErrTypeMismatch)) {
+				t.Errorf("expected a type mismatch for %q, got %v", test.field, decode)
+			}
+
+			if target.Address.City != "Toronto" || target.Port != 80 {
+				t.Errorf("expected the target to be unmodified, got %+v", target)
+			}
+		})
+	}
+
+	// --> numeric value(s) still convert between numeric type(s)
+	target := contact{}
+	if e := Apply(&target, map[string]interface{}{"port": float64(8443), "ratio": 1, "tags": []interface{}{"a"}, "enabled": true}); e != nil {
+		t.Fatalf("unexpected error: %v", e)
+	}
+
+	if target.Port != 8443 || target.Ratio != 1 || len(target.Tags) != 1 || !(target.Enabled) {
+		t.Errorf("unexpected result: %+v", target)
+	}
+
+	// --> the coercion remains available to Decode
+	var decoded address
+	if e := Decode(map[string]interface{}{"city": 1}, &decoded); e != nil || decoded.City != "1" {
+		t.Errorf("expected Decode to coerce, got %+v (%v)", decoded, e)
+	}
+}
+
+func TestApplyAtomic(t *testing.T) {
+	target := settings{Name: "playground", Port: 8080}
+
+	var decode *DecodeError
+	if e := Apply(&target, map[string]interface{}{"name": "updated", "port": "invalid", "unknown": true}); !(errors.As(e, &decode)) {
+		t.Fatalf("expected a DecodeError, got %v", e)
+	}
+
+	if len(decode.Unknown) != 1 || len(decode.Invalid) != 1 {
+		t.Errorf("expected one unknown and one invalid field, got %v", decode)
+	}
+
+	if target.Name != "playground" || target.Port != 8080 {
+		t.Errorf("expected the target to be unmodified, got %+v", target)
+	}
+}
@@ -7,7 +7,8 @@ import (
 var uppercaseAcronym = sync.Map{}
 	//"ID": "id",
 
-// ConfigureAcronym allows you to add additional words which will be considered acronyms
+// ConfigureAcronym allows you to add additional words which will be considered acronyms.
+// It modifies the default [Converter] for the whole process; prefer a [Builder] for isolated conventions.
 func ConfigureAcronym(key, val string) {
 	uppercaseAcronym.Store(key, val)
 }
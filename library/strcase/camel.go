@@ -27,47 +27,62 @@ package strcase
 
 import (
 	"strings"
+	"unicode"
 )
 
 // Converts a string to CamelCase
-func toCamelInitCase(s string, initCase bool) string {
+func (c *Converter) toCamelInitCase(s string, initCase bool) string {
 	s = strings.TrimSpace(s)
 	if s == "" {
 		return s
 	}
-	a, hasAcronym := uppercaseAcronym.Load(s)
-	if hasAcronym {
-		s = a.(string)
+	if a, hasAcronym := c.acronyms.Load(s); hasAcronym {
+		return toCamelAcronym(a.(string), initCase)
 	}
 
+	n := strings.Builder{}
+	n.Grow(len(s))
+	first := true
+	for _, t := range c.split(s, "") {
+		// non-alphanumeric literals are dropped
+		if t.literal {
+			continue
+		}
+
+		if first && !initCase {
+			n.WriteString(strings.ToLower(string(t.text)))
+		} else {
+			n.WriteString(c.capitalize(t.text))
+		}
+		first = false
+	}
+	return n.String()
+}
+
+// Converts a configured acronym's value to CamelCase, preserving its casing
+func toCamelAcronym(s string, initCase bool) string {
 	n := strings.Builder{}
 	n.Grow(len(s))
 	capNext := initCase
-	prevIsCap := false
-	for i, v := range []byte(s) {
-		vIsCap := v >= 'A' && v <= 'Z'
-		vIsLow := v >= 'a' && v <= 'z'
+	for i, v := range []rune(s) {
+		vIsCap := unicode.IsUpper(v)
+		vIsLow := unicode.IsLower(v)
 		if capNext {
 			if vIsLow {
-				v += 'A'
-				v -= 'a'
+				v = unicode.ToTitle(v)
 			}
 		} else if i == 0 {
 			if vIsCap {
-				v += 'a'
-				v -= 'A'
+				v = unicode.ToLower(v)
 			}
-		} else if prevIsCap && vIsCap && !hasAcronym {
-			v += 'a'
-			v -= 'A'
 		}
-		prevIsCap = vIsCap
 
-		if vIsCap || vIsLow {
-			n.WriteByte(v)
+		// letters without case (e.g. CJK ideographs) are kept as-is
+		if vIsCap || vIsLow || unicode.IsLetter(v) {
+			n.WriteRune(v)
 			capNext = false
-		} else if vIsNum := v >= '0' && v <= '9'; vIsNum {
-			n.WriteByte(v)
+		} else if vIsNum := unicode.IsDigit(v); vIsNum {
+			n.WriteRune(v)
 			capNext = true
 		} else {
 			capNext = v == '_' || v == ' ' || v == '-' || v == '.'
@@ -77,11 +92,21 @@ func toCamelInitCase(s string, initCase bool) string {
 }
 
 // ToCamel converts a string to CamelCase
-func ToCamel(s string) string {
-	return toCamelInitCase(s, true)
+func (c *Converter) ToCamel(s string) string {
+	return c.toCamelInitCase(s, true)
 }
 
 // ToLowerCamel converts a string to lowerCamelCase
+func (c *Converter) ToLowerCamel(s string) string {
+	return c.toCamelInitCase(s, false)
+}
+
+// ToCamel converts a string to CamelCase using the default [Converter]
+func ToCamel(s string) string {
+	return standard.ToCamel(s)
+}
+
+// ToLowerCamel converts a string to lowerCamelCase using the default [Converter]
 func ToLowerCamel(s string) string {
-	return toCamelInitCase(s, false)
+	return standard.ToLowerCamel(s)
 }
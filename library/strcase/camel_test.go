@@ -40,7 +40,7 @@ func toCamel(tb testing.TB) {
 		{"AnyKind of_string", "AnyKindOfString"},
 		{"odd-fix", "OddFix"},
 		{"numbers2And55with000", "Numbers2And55With000"},
-		{"ID", "Id"},
+		{"ID", "ID"},
 		{"CONSTANT_CASE", "ConstantCase"},
 	}
 	for _, i := range cases {
@@ -0,0 +1,75 @@
+package strcase
+
+import (
+	"sync"
+)
+
+// Converter converts strings between cases using its own acronym and initialism tables. A Converter constructed via
+// [Builder.Build] is immutable and safe for concurrent use; the package-level functions delegate to a default Converter,
+// which [ConfigureAcronym], [ConfigureInitialism] and [RemoveInitialism] modify.
+type Converter struct {
+	acronyms    *sync.Map   // acronyms maps whole input(s) to their CamelCase spelling; see [Builder.Acronym].
+	initialisms *dictionary // initialisms is the per-word initialism dictionary; see [Builder.Initialisms].
+}
+
+// standard is the default [Converter] used by the package-level functions.
+var standard = &Converter{acronyms: &uppercaseAcronym, initialisms: catalog(defaults...)}
+
+// Builder configures a [Converter]. Building copies the configuration, so a Builder can be reused and modified without
+// affecting previously built converters.
+type Builder struct {
+	acronyms    map[string]string
+	initialisms []string
+	excluded    map[string]struct{}
+}
+
+// NewBuilder returns a [Builder] seeded with the default go-style initialisms (ID, URL, HTTP, JSON, API, ...) and no acronyms.
+func NewBuilder() *Builder {
+	return &Builder{
+		acronyms:    make(map[string]string),
+		initialisms: append([]string(nil), defaults...),
+		excluded:    make(map[string]struct{}),
+	}
+}
+
+// Acronym maps an entire input to its CamelCase spelling - e.g. Acronym("API", "api") - mirroring [ConfigureAcronym].
+func (b *Builder) Acronym(key, value string) *Builder {
+	b.acronyms[key] = value
+	return b
+}
+
+// Initialisms adds per-word initialism(s), mirroring [ConfigureInitialism].
+func (b *Builder) Initialisms(words ...string) *Builder {
+	for _, word := range words {
+		delete(b.excluded, word)
+	}
+
+	b.initialisms = append(b.initialisms, words...)
+	return b
+}
+
+// Without removes initialism(s) - including any of the defaults - mirroring [RemoveInitialism].
+func (b *Builder) Without(words ...string) *Builder {
+	for _, word := range words {
+		b.excluded[word] = struct{}{}
+	}
+
+	return b
+}
+
+// Build returns a new, immutable [Converter].
+func (b *Builder) Build() *Converter {
+	c := &Converter{acronyms: &sync.Map{}, initialisms: catalog(b.initialisms...)}
+
+	for key, value := range b.acronyms {
+		c.acronyms.Store(key, value)
+	}
+
+	c.initialisms.mutex.Lock()
+	for word := range b.excluded {
+		c.initialisms.remove(word)
+	}
+	c.initialisms.mutex.Unlock()
+
+	return c
+}
@@ -0,0 +1,60 @@
+package strcase
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestConverterIsolation(t *testing.T) {
+	builder := NewBuilder().Acronym("K8S", "Kubernetes").Initialisms("GraphQL").Without("ID")
+	converter := builder.Build()
+
+	// --> modifying the builder afterwards must not affect the built converter
+	builder.Acronym("K8S", "K8s").Without("GraphQL")
+
+	cases := [][]string{
+		{"K8S", "Kubernetes"},
+		{"user_id", "UserId"},
+		{"graphql_schema", "GraphQLSchema"},
+		{"http_server", "HTTPServer"},
+	}
+	for _, i := range cases {
+		if result := converter.ToCamel(i[0]); result != i[1] {
+			t.Errorf("%q (%q != %q)", i[0], result, i[1])
+		}
+	}
+
+	// --> process-wide configuration must not affect built converters, nor built converters the default
+	ConfigureAcronym("user_id", "USERID")
+	defer uppercaseAcronym.Delete("user_id")
+
+	if result := converter.ToCamel("user_id"); result != "UserId" {
+		t.Errorf("expected the converter to ignore global acronyms, got %q", result)
+	}
+
+	if result := ToCamel("graphql_schema"); result != "GraphqlSchema" {
+		t.Errorf("expected the default converter to ignore the builder's initialisms, got %q", result)
+	}
+}
+
+func TestConverterConcurrency(t *testing.T) {
+	converter := NewBuilder().Acronym("pe_ratio", "PERatio").Build()
+
+	var group sync.WaitGroup
+	for i := 0; i < 100; i++ {
+		group.Add(1)
+		go func() {
+			defer group.Done()
+
+			if result := converter.ToCamel("pe_ratio"); result != "PERatio" {
+				t.Errorf("unexpected result %q", result)
+			}
+
+			if result := converter.ToSnake("UserIDToken"); result != "user_id_token" {
+				t.Errorf("unexpected result %q", result)
+			}
+		}()
+	}
+
+	group.Wait()
+}
@@ -9,4 +9,9 @@
 //   | ToScreamingDelimited(s, '.')    | ANY.KIND.OF.STRING |
 //   | ToCamel(s)                      | AnyKindOfString    |
 //   | ToLowerCamel(s)                 | anyKindOfString    |
+//   | ToTitle(s)                      | Any Kind Of String |
+//   | ToTrain(s)                      | Any-Kind-Of-String |
+//   | ToDot(s)                        | any.kind.of.string |
+//   | ToPath(s)                       | any/kind/of/string |
+//   | ToGoIdentifier(s, false)        | anyKindOfString    |
 package strcase
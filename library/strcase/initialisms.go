@@ -0,0 +1,183 @@
+package strcase
+
+import (
+	"strings"
+	"sync"
+	"unicode"
+)
+
+// defaults are the go-style initialisms recognized out of the box, e.g. "UserID" <-> "user_id".
+var defaults = []string{
+	"ACL", "API", "ASCII", "CPU", "CSS", "DNS", "EOF", "GUID", "HTML", "HTTP", "HTTPS", "ID", "IP", "IPv4", "IPv6", "JSON",
+	"JWT", "LHS", "QPS", "RAM", "RHS", "RPC", "SLA", "SMTP", "SQL", "SSH", "TCP", "TLS", "TTL", "UDP", "UI", "UID", "UUID",
+	"URI", "URL", "UTF8", "VM", "XML", "XMPP", "XSRF", "XSS",
+}
+
+// dictionary is the per-word initialism set consulted while splitting and joining words.
+type dictionary struct {
+	mutex sync.RWMutex
+	words map[string]string // words maps an initialism's lowercase form to its canonical spelling, e.g. "id" -> "ID".
+	mixed [][]rune          // mixed lists canonical spellings that aren't all-uppercase letters, e.g. "PostgreSQL" or "UTF8".
+}
+
+func (d *dictionary) add(words ...string) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	for _, word := range words {
+		if word = strings.TrimSpace(word); word == "" {
+			continue
+		}
+
+		d.remove(word)
+
+		d.words[strings.ToLower(word)] = word
+		if word != strings.ToUpper(word) || strings.IndexFunc(word, func(r rune) bool { return !(unicode.IsLetter(r)) }) >= 0 {
+			d.mixed = append(d.mixed, []rune(word))
+		}
+	}
+}
+
+// remove deletes the word; callers must hold the write lock.
+func (d *dictionary) remove(word string) {
+	key := strings.ToLower(word)
+
+	delete(d.words, key)
+
+	for index := 0; index < len(d.mixed); index++ {
+		if strings.ToLower(string(d.mixed[index])) == key {
+			d.mixed = append(d.mixed[:index], d.mixed[index+1:]...)
+			index--
+		}
+	}
+}
+
+// canonical returns the initialism's canonical spelling, if the word is one.
+func (d *dictionary) canonical(word string) (string, bool) {
+	d.mutex.RLock()
+	defer d.mutex.RUnlock()
+
+	value, ok := d.words[strings.ToLower(word)]
+
+	return value, ok
+}
+
+// exact returns the length of the mixed-case initialism spelled at runes[i:], provided it isn't immediately followed by a
+// lowercase letter; otherwise 0. Besides the canonical spelling, the all-uppercase and all-lowercase forms match - the latter
+// only when not followed by any letter, e.g. "utf8" in "parse_utf8". A trailing plural "s" is included in the length, e.g.
+// "IPv6s" in "ListIPv6sFor".
+func (d *dictionary) exact(runes []rune, i int) int {
+	d.mutex.RLock()
+	defer d.mutex.RUnlock()
+
+	// --> follows reports whether the rune at position is absent or fails the boundary check
+	follows := func(position int, boundary func(rune) bool) bool {
+		return position >= len(runes) || !(boundary(runes[position]))
+	}
+
+	longest, length := 0, 0
+	for _, word := range d.mixed {
+		end := i + len(word)
+		if len(word) <= longest || end > len(runes) {
+			continue
+		}
+
+		var boundary func(rune) bool
+
+		candidate, canonical := string(runes[i:end]), string(word)
+		switch {
+		case candidate == canonical || candidate == strings.ToUpper(canonical):
+			boundary = unicode.IsLower
+		case candidate == strings.ToLower(canonical):
+			boundary = unicode.IsLetter
+		default:
+			continue
+		}
+
+		switch {
+		case follows(end, boundary):
+			longest, length = len(word), len(word)
+		case runes[end] == 's' && follows(end+1, boundary):
+			longest, length = len(word), len(word)+1
+		}
+	}
+
+	return length
+}
+
+// segment splits an uppercase run (e.g. "JSONAPI") into initialisms, preferring the longest match at each position. It
+// returns nil unless the entire run is covered - so ordinary SCREAMING words such as "VALID" are never split.
+func (d *dictionary) segment(run []rune) [][]rune {
+	d.mutex.RLock()
+	defer d.mutex.RUnlock()
+
+	var segments [][]rune
+
+	// --> memo[i] records that run[i:] can't be segmented
+	memo := make(map[int]bool)
+
+	var walk func(i int) bool
+	walk = func(i int) bool {
+		if i == len(run) {
+			return true
+		}
+
+		if memo[i] {
+			return false
+		}
+
+		for end := len(run); end > i+1; end-- {
+			if _, ok := d.words[strings.ToLower(string(run[i:end]))]; !(ok) {
+				continue
+			}
+
+			segments = append(segments, run[i:end])
+			if walk(end) {
+				return true
+			}
+
+			segments = segments[:len(segments)-1]
+		}
+
+		memo[i] = true
+
+		return false
+	}
+
+	if !(walk(0)) {
+		return nil
+	}
+
+	return segments
+}
+
+// catalog constructs a [dictionary] containing the word(s).
+func catalog(words ...string) *dictionary {
+	d := &dictionary{words: make(map[string]string)}
+
+	d.add(words...)
+
+	return d
+}
+
+// ConfigureInitialism adds word(s) to the per-word initialism dictionary, using each word's casing as its canonical spelling
+// (e.g. "GraphQL", "OAuth", "K8S"). Initialisms are kept whole when splitting - "JSONAPIClient" -> "json_api_client" - and
+// restored when joining - "user_id" -> "UserID".
+//
+// Unlike [ConfigureAcronym], which only applies when an entire input matches, initialisms apply to each word of any input.
+//
+// ConfigureInitialism modifies the default [Converter] for the whole process; prefer a [Builder] for isolated conventions.
+func ConfigureInitialism(words ...string) {
+	standard.initialisms.add(words...)
+}
+
+// RemoveInitialism removes word(s) - including any of the defaults, e.g. "ID" to produce "UserId" - from the per-word
+// initialism dictionary.
+func RemoveInitialism(words ...string) {
+	standard.initialisms.mutex.Lock()
+	defer standard.initialisms.mutex.Unlock()
+
+	for _, word := range words {
+		standard.initialisms.remove(word)
+	}
+}
@@ -0,0 +1,84 @@
+package strcase
+
+import (
+	"testing"
+)
+
+func toInitialisms(tb testing.TB) {
+	cases := [][]string{
+		// input, snake, camel
+		{"UserIDToken", "user_id_token", "UserIDToken"},
+		{"user_id", "user_id", "UserID"},
+		{"HTTPServer", "http_server", "HTTPServer"},
+		{"JSONAPIClient", "json_api_client", "JSONAPIClient"},
+		{"ServeHTTPS", "serve_https", "ServeHTTPS"},
+		{"ParseUTF8", "parse_utf8", "ParseUTF8"},
+		{"UserIDs", "user_ids", "UserIDs"},
+		{"GetURLsFor", "get_urls_for", "GetURLsFor"},
+		{"IPv4Address", "ipv4_address", "IPv4Address"},
+		{"ListIPv6s", "list_ipv6s", "ListIPv6s"},
+		{"user_uids", "user_uids", "UserUIDs"},
+		{"Bus", "bus", "Bus"},
+		{"CPUID", "cpu_id", "CPUID"},
+		{"VALID_VALUE", "valid_value", "ValidValue"},
+		{"Identity", "identity", "Identity"},
+		{"api url", "api_url", "APIURL"},
+	}
+	for _, i := range cases {
+		if result := ToSnake(i[0]); result != i[1] {
+			tb.Errorf("ToSnake %q (%q != %q)", i[0], result, i[1])
+		}
+		if result := ToCamel(i[0]); result != i[2] {
+			tb.Errorf("ToCamel %q (%q != %q)", i[0], result, i[2])
+		}
+	}
+}
+
+func TestInitialisms(t *testing.T) { toInitialisms(t) }
+
+func BenchmarkInitialisms(b *testing.B) {
+	for n := 0; n < b.N; n++ {
+		toInitialisms(b)
+	}
+}
+
+func TestInitialismsRoundTrip(t *testing.T) {
+	identifiers := []string{"UserID", "HTTPServerURL", "JSONAPIClient", "ParseUTF8", "NewTCPConn", "XMLHTTPRequest", "ServeHTTP", "TTLCache", "UserIDs", "GetURLsFor", "IPv4Address", "APIsByID"}
+	for _, identifier := range identifiers {
+		if result := ToCamel(ToSnake(identifier)); result != identifier {
+			t.Errorf("ToCamel(ToSnake(%q)) = %q", identifier, result)
+		}
+
+		if result := ToCamel(ToKebab(identifier)); result != identifier {
+			t.Errorf("ToCamel(ToKebab(%q)) = %q", identifier, result)
+		}
+	}
+
+	if result := ToLowerCamel("http_server_url"); result != "httpServerURL" {
+		t.Errorf("expected a leading initialism to be lowercased, got %q", result)
+	}
+
+	if result := ToLowerCamel("user_ids"); result != "userIDs" {
+		t.Errorf("expected a plural initialism's canonical spelling, got %q", result)
+	}
+}
+
+func TestConfigureInitialism(t *testing.T) {
+	ConfigureInitialism("PostgreSQL", "GraphQL")
+	defer RemoveInitialism("PostgreSQL", "GraphQL")
+
+	if result := ToSnake("NewPostgreSQLDriver"); result != "new_postgresql_driver" {
+		t.Errorf("expected a mixed-case initialism to be kept whole, got %q", result)
+	}
+
+	if result := ToCamel("graphql_schema"); result != "GraphQLSchema" {
+		t.Errorf("expected a mixed-case initialism's canonical spelling, got %q", result)
+	}
+
+	RemoveInitialism("ID")
+	defer ConfigureInitialism("ID")
+
+	if result := ToCamel("user_id"); result != "UserId" {
+		t.Errorf("expected a removed initialism to be title-cased, got %q", result)
+	}
+}
@@ -30,86 +30,93 @@ import (
 )
 
 // ToSnake converts a string to snake_case
-func ToSnake(s string) string {
-	return ToDelimited(s, '_')
+func (c *Converter) ToSnake(s string) string {
+	return c.ToDelimited(s, '_')
 }
 
-func ToSnakeWithIgnore(s string, ignore string) string {
-	return ToScreamingDelimited(s, '_', ignore, false)
+// ToSnakeWithIgnore converts a string to snake_case, keeping rune(s) in ignore as-is
+func (c *Converter) ToSnakeWithIgnore(s string, ignore string) string {
+	return c.ToScreamingDelimited(s, '_', ignore, false)
 }
 
 // ToScreamingSnake converts a string to SCREAMING_SNAKE_CASE
-func ToScreamingSnake(s string) string {
-	return ToScreamingDelimited(s, '_', "", true)
+func (c *Converter) ToScreamingSnake(s string) string {
+	return c.ToScreamingDelimited(s, '_', "", true)
 }
 
 // ToKebab converts a string to kebab-case
-func ToKebab(s string) string {
-	return ToDelimited(s, '-')
+func (c *Converter) ToKebab(s string) string {
+	return c.ToDelimited(s, '-')
 }
 
 // ToScreamingKebab converts a string to SCREAMING-KEBAB-CASE
-func ToScreamingKebab(s string) string {
-	return ToScreamingDelimited(s, '-', "", true)
+func (c *Converter) ToScreamingKebab(s string) string {
+	return c.ToScreamingDelimited(s, '-', "", true)
 }
 
 // ToDelimited converts a string to delimited.snake.case
 // (in this case `delimiter = '.'`)
-func ToDelimited(s string, delimiter uint8) string {
-	return ToScreamingDelimited(s, delimiter, "", false)
+func (c *Converter) ToDelimited(s string, delimiter uint8) string {
+	return c.ToScreamingDelimited(s, delimiter, "", false)
 }
 
 // ToScreamingDelimited converts a string to SCREAMING.DELIMITED.SNAKE.CASE
 // (in this case `delimiter = '.'; screaming = true`)
 // or delimited.snake.case
 // (in this case `delimiter = '.'; screaming = false`)
-func ToScreamingDelimited(s string, delimiter uint8, ignore string, screaming bool) string {
-	s = strings.TrimSpace(s)
+func (c *Converter) ToScreamingDelimited(s string, delimiter uint8, ignore string, screaming bool) string {
 	n := strings.Builder{}
 	n.Grow(len(s) + 2) // nominal 2 bytes of extra space for inserted delimiters
-	for i, v := range []byte(s) {
-		vIsCap := v >= 'A' && v <= 'Z'
-		vIsLow := v >= 'a' && v <= 'z'
-		if vIsLow && screaming {
-			v += 'A'
-			v -= 'a'
-		} else if vIsCap && !screaming {
-			v += 'a'
-			v -= 'A'
-		}
-
-		// treat acronyms as words, eg for JSONData -> JSON is a whole word
-		if i+1 < len(s) {
-			next := s[i+1]
-			vIsNum := v >= '0' && v <= '9'
-			nextIsCap := next >= 'A' && next <= 'Z'
-			nextIsLow := next >= 'a' && next <= 'z'
-			nextIsNum := next >= '0' && next <= '9'
-			// add underscore if next letter case type is changed
-			if (vIsCap && (nextIsLow || nextIsNum)) || (vIsLow && (nextIsCap || nextIsNum)) || (vIsNum && (nextIsCap || nextIsLow)) {
-				prevIgnore := ignore != "" && i > 0 && strings.ContainsAny(string(s[i-1]), ignore)
-				if !prevIgnore {
-					if vIsCap && nextIsLow {
-						if prevIsCap := i > 0 && s[i-1] >= 'A' && s[i-1] <= 'Z'; prevIsCap {
-							n.WriteByte(delimiter)
-						}
-					}
-					n.WriteByte(v)
-					if vIsLow || vIsNum || nextIsNum {
-						n.WriteByte(delimiter)
-					}
-					continue
-				}
-			}
+	tokens := c.split(s, ignore)
+	for i, t := range tokens {
+		// words are delimited from one another, while literals are only delimited where the input had a separator
+		if i > 0 && (t.separated || (!t.literal && !tokens[i-1].literal)) {
+			n.WriteByte(delimiter)
 		}
 
-		if (v == ' ' || v == '_' || v == '-' || v == '.') && !strings.ContainsAny(string(v), ignore) {
-			// replace space/underscore/hyphen/dot with delimiter
-			n.WriteByte(delimiter)
-		} else {
-			n.WriteByte(v)
+		switch {
+		case t.literal:
+			n.WriteString(string(t.text))
+		case screaming:
+			n.WriteString(strings.ToUpper(string(t.text)))
+		default:
+			n.WriteString(strings.ToLower(string(t.text)))
 		}
 	}
 
 	return n.String()
 }
+
+// ToSnake converts a string to snake_case using the default [Converter]
+func ToSnake(s string) string {
+	return standard.ToSnake(s)
+}
+
+func ToSnakeWithIgnore(s string, ignore string) string {
+	return standard.ToSnakeWithIgnore(s, ignore)
+}
+
+// ToScreamingSnake converts a string to SCREAMING_SNAKE_CASE using the default [Converter]
+func ToScreamingSnake(s string) string {
+	return standard.ToScreamingSnake(s)
+}
+
+// ToKebab converts a string to kebab-case using the default [Converter]
+func ToKebab(s string) string {
+	return standard.ToKebab(s)
+}
+
+// ToScreamingKebab converts a string to SCREAMING-KEBAB-CASE using the default [Converter]
+func ToScreamingKebab(s string) string {
+	return standard.ToScreamingKebab(s)
+}
+
+// ToDelimited converts a string to delimited.snake.case using the default [Converter]
+func ToDelimited(s string, delimiter uint8) string {
+	return standard.ToDelimited(s, delimiter)
+}
+
+// ToScreamingDelimited converts a string to SCREAMING.DELIMITED.SNAKE.CASE or delimited.snake.case using the default [Converter]
+func ToScreamingDelimited(s string, delimiter uint8, ignore string, screaming bool) string {
+	return standard.ToScreamingDelimited(s, delimiter, ignore, screaming)
+}
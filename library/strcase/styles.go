@@ -0,0 +1,106 @@
+package strcase
+
+import (
+	gotoken "go/token"
+	"strings"
+	"unicode"
+)
+
+// join converts the words of s using format, joined by the delimiter. Literals (non-alphanumeric runes) are dropped.
+func (c *Converter) join(s string, delimiter string, format func(index int, word []rune) string) string {
+	n := strings.Builder{}
+	n.Grow(len(s) + 2)
+	index := 0
+	for _, t := range c.split(s, "") {
+		if t.literal {
+			continue
+		}
+		if index > 0 {
+			n.WriteString(delimiter)
+		}
+		n.WriteString(format(index, t.text))
+		index++
+	}
+	return n.String()
+}
+
+// ToTitle converts a string to Title Case, e.g. "User Display Name" - initialisms keep their canonical spelling ("User ID")
+func (c *Converter) ToTitle(s string) string {
+	return c.join(s, " ", func(_ int, word []rune) string {
+		return c.capitalize(word)
+	})
+}
+
+// ToTrain converts a string to Train-Case, e.g. "User-Display-Name". Initialisms aren't applied, so the result matches
+// [net/http.CanonicalHeaderKey] ("X-Request-Id").
+func (c *Converter) ToTrain(s string) string {
+	return c.join(s, "-", func(_ int, word []rune) string {
+		return string(unicode.ToTitle(word[0])) + strings.ToLower(string(word[1:]))
+	})
+}
+
+// ToDot converts a string to dot.case, e.g. "user.display.name"
+func (c *Converter) ToDot(s string) string {
+	return c.ToDelimited(s, '.')
+}
+
+// ToPath converts a string to path/case, e.g. "user/display/name". Existing slashes are kept as-is.
+func (c *Converter) ToPath(s string) string {
+	return c.ToDelimited(s, '/')
+}
+
+// ToGoIdentifier converts a string to a valid go identifier - CamelCase if exported, otherwise lowerCamelCase. Keywords
+// receive a trailing underscore ("type" -> "type_"). Leading digits, and input(s) without any letters or digits, receive
+// a leading underscore ("2fa" -> "_2Fa", "--" -> "_") - or, so the result remains exported, an "X" ("2fa" -> "X2Fa"). An
+// exported identifier also receives the "X" whenever its first rune isn't uppercase, e.g. caseless scripts ("東京" -> "X東京").
+func (c *Converter) ToGoIdentifier(s string, exported bool) string {
+	var identifier string
+	if exported {
+		identifier = c.ToCamel(s)
+	} else {
+		identifier = c.ToLowerCamel(s)
+	}
+
+	prefix := "_"
+	if exported {
+		prefix = "X"
+	}
+
+	switch {
+	case identifier == "":
+		return prefix
+	case gotoken.IsKeyword(identifier):
+		return identifier + "_"
+	case unicode.IsDigit([]rune(identifier)[0]):
+		return prefix + identifier
+	case exported && !(unicode.IsUpper([]rune(identifier)[0])):
+		return prefix + identifier
+	}
+
+	return identifier
+}
+
+// ToTitle converts a string to Title Case using the default [Converter]
+func ToTitle(s string) string {
+	return standard.ToTitle(s)
+}
+
+// ToTrain converts a string to Train-Case using the default [Converter]
+func ToTrain(s string) string {
+	return standard.ToTrain(s)
+}
+
+// ToDot converts a string to dot.case using the default [Converter]
+func ToDot(s string) string {
+	return standard.ToDot(s)
+}
+
+// ToPath converts a string to path/case using the default [Converter]
+func ToPath(s string) string {
+	return standard.ToPath(s)
+}
+
+// ToGoIdentifier converts a string to a valid go identifier using the default [Converter]
+func ToGoIdentifier(s string, exported bool) string {
+	return standard.ToGoIdentifier(s, exported)
+}
@@ -0,0 +1,85 @@
+package strcase
+
+import (
+	gotoken "go/token"
+	"net/http"
+	"testing"
+)
+
+func toStyles(tb testing.TB) {
+	cases := []struct {
+		in, title, train, dot, path string
+	}{
+		{"userDisplayName", "User Display Name", "User-Display-Name", "user.display.name", "user/display/name"},
+		{"user_id", "User ID", "User-Id", "user.id", "user/id"},
+		{"x-request-id", "X Request ID", "X-Request-Id", "x.request.id", "x/request/id"},
+		{"HTTPServer config", "HTTP Server Config", "Http-Server-Config", "http.server.config", "http/server/config"},
+		{"api/UserProfile", "API User Profile", "Api-User-Profile", "api/user.profile", "api/user/profile"},
+		{"", "", "", "", ""},
+	}
+	for _, i := range cases {
+		if result := ToTitle(i.in); result != i.title {
+			tb.Errorf("ToTitle %q (%q != %q)", i.in, result, i.title)
+		}
+		if result := ToTrain(i.in); result != i.train {
+			tb.Errorf("ToTrain %q (%q != %q)", i.in, result, i.train)
+		}
+		if result := ToDot(i.in); result != i.dot {
+			tb.Errorf("ToDot %q (%q != %q)", i.in, result, i.dot)
+		}
+		if result := ToPath(i.in); result != i.path {
+			tb.Errorf("ToPath %q (%q != %q)", i.in, result, i.path)
+		}
+	}
+}
+
+func TestStyles(t *testing.T) { toStyles(t) }
+
+func BenchmarkStyles(b *testing.B) {
+	for n := 0; n < b.N; n++ {
+		toStyles(b)
+	}
+}
+
+func TestToTrainCanonicalHeaderKey(t *testing.T) {
+	for _, header := range []string{"content-type", "x-request-id", "X-FORWARDED-FOR", "www-authenticate"} {
+		if result, expected := ToTrain(header), http.CanonicalHeaderKey(header); result != expected {
+			t.Errorf("%q (%q != %q)", header, result, expected)
+		}
+	}
+}
+
+func TestToGoIdentifier(t *testing.T) {
+	cases := []struct {
+		in       string
+		exported bool
+		out      string
+	}{
+		{"user_id", true, "UserID"},
+		{"user_id", false, "userID"},
+		{"type", false, "type_"},
+		{"func", false, "func_"},
+		{"type", true, "Type"},
+		{"2fa-enabled", false, "_2FaEnabled"},
+		{"2fa-enabled", true, "X2FaEnabled"},
+		{"1abc", true, "X1Abc"},
+		{"--", false, "_"},
+		{"--", true, "X"},
+		{"größe", true, "Größe"},
+		{"東京Tower", true, "X東京Tower"},
+		{"東京Tower", false, "東京Tower"},
+		{"_private", true, "Private"},
+	}
+	for _, i := range cases {
+		result := ToGoIdentifier(i.in, i.exported)
+		if result != i.out {
+			t.Errorf("%q (%q != %q)", i.in, result, i.out)
+		}
+		if !gotoken.IsIdentifier(result) {
+			t.Errorf("%q is not a valid go identifier", result)
+		}
+		if i.exported && !gotoken.IsExported(result) {
+			t.Errorf("%q is not an exported go identifier", result)
+		}
+	}
+}
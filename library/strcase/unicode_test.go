@@ -0,0 +1,58 @@
+package strcase
+
+import (
+	"testing"
+)
+
+func toUnicode(tb testing.TB) {
+	cases := []struct {
+		in, snake, screaming, camel, lower string
+	}{
+		// German - umlauts and sharp s (which has no single-rune uppercase mapping)
+		{"ÜberGröße", "über_größe", "ÜBER_GRÖßE", "ÜberGröße", "überGröße"},
+		{"straßeName", "straße_name", "STRAßE_NAME", "StraßeName", "straßeName"},
+		{"größe änderung", "größe_änderung", "GRÖßE_ÄNDERUNG", "GrößeÄnderung", "größeÄnderung"},
+		// Turkish - dotted capital I and dotless lowercase i (using unicode's locale-independent case mapping)
+		{"İstanbulŞehri", "istanbul_şehri", "İSTANBUL_ŞEHRI", "İstanbulŞehri", "istanbulŞehri"},
+		{"ılıkSu", "ılık_su", "ILIK_SU", "IlıkSu", "ılıkSu"},
+		// CJK - letters without case are kept as-is, and behave as lowercase at word boundaries
+		{"用户Name", "用户_name", "用户_NAME", "用户Name", "用户Name"},
+		{"用户 名称", "用户_名称", "用户_名称", "用户名称", "用户名称"},
+		{"日本語テキスト", "日本語テキスト", "日本語テキスト", "日本語テキスト", "日本語テキスト"},
+	}
+	for _, i := range cases {
+		if result := ToSnake(i.in); result != i.snake {
+			tb.Errorf("ToSnake %q (%q != %q)", i.in, result, i.snake)
+		}
+		if result := ToScreamingSnake(i.in); result != i.screaming {
+			tb.Errorf("ToScreamingSnake %q (%q != %q)", i.in, result, i.screaming)
+		}
+		if result := ToCamel(i.in); result != i.camel {
+			tb.Errorf("ToCamel %q (%q != %q)", i.in, result, i.camel)
+		}
+		if result := ToLowerCamel(i.in); result != i.lower {
+			tb.Errorf("ToLowerCamel %q (%q != %q)", i.in, result, i.lower)
+		}
+	}
+}
+
+func TestUnicode(t *testing.T) { toUnicode(t) }
+
+func BenchmarkUnicode(b *testing.B) {
+	for n := 0; n < b.N; n++ {
+		toUnicode(b)
+	}
+}
+
+func TestUnicodeIgnore(t *testing.T) {
+	cases := [][]string{
+		{"Größe→ÄnderungÜber", "→", "größe→änderung_über"},
+		{"größe·Änderung über_alles", "·", "größe·änderung_über_alles"},
+		{"用户。名称", "。", "用户。名称"},
+	}
+	for _, i := range cases {
+		if result := ToSnakeWithIgnore(i[0], i[1]); result != i[2] {
+			t.Errorf("%q (%q != %q)", i[0], result, i[2])
+		}
+	}
+}
@@ -0,0 +1,127 @@
+package token
+
+import (
+	"encoding/json"
+	"slices"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// registered is the set of json keys owned by the [Claims] structure; all other keys are considered custom.
+var registered = []string{"sub", "iss", "aud", "scopes", "roles", "iat", "nbf", "exp", "jti"}
+
+// Claims represents the typed jwt claims structure issued and verified by the package.
+type Claims struct {
+	Subject   string           `json:"sub,omitempty"`    // Subject represents the principal of the token - typically the user's email address.
+	Issuer    string           `json:"iss,omitempty"`    // Issuer is the issuing service that generated the token.
+	Audience  jwt.ClaimStrings `json:"aud,omitempty"`    // Audience is the list of recipients the token is intended for.
+	Scopes    []string         `json:"scopes,omitempty"` // Scopes represents the permission scope(s) granted to the token's bearer.
+	Roles     []string         `json:"roles,omitempty"`  // Roles represents the role(s) assigned to the subject (e.g. "MEMBER", "ROOT").
+	IssuedAt  *jwt.NumericDate `json:"iat,omitempty"`    // IssuedAt is the time the token was minted.
+	NotBefore *jwt.NumericDate `json:"nbf,omitempty"`    // NotBefore is the time before which the token must be rejected.
+	ExpiresAt *jwt.NumericDate `json:"exp,omitempty"`    // ExpiresAt is the time after which the token must be rejected.
+	ID        string           `json:"jti,omitempty"`    // ID represents the token's unique identifier.
+
+	Custom map[string]interface{} `json:"-"` // Custom represents all non-registered claims, flattened into the top-level jwt claims structure.
+}
+
+// GetExpirationTime implements the [jwt.Claims] interface.
+func (c *Claims) GetExpirationTime() (*jwt.NumericDate, error) {
+	return c.ExpiresAt, nil
+}
+
+// GetIssuedAt implements the [jwt.Claims] interface.
+func (c *Claims) GetIssuedAt() (*jwt.NumericDate, error) {
+	return c.IssuedAt, nil
+}
+
+// GetNotBefore implements the [jwt.Claims] interface.
+func (c *Claims) GetNotBefore() (*jwt.NumericDate, error) {
+	return c.NotBefore, nil
+}
+
+// GetIssuer implements the [jwt.Claims] interface.
+func (c *Claims) GetIssuer() (string, error) {
+	return c.Issuer, nil
+}
+
+// GetSubject implements the [jwt.Claims] interface.
+func (c *Claims) GetSubject() (string, error) {
+	return c.Subject, nil
+}
+
+// GetAudience implements the [jwt.Claims] interface.
+func (c *Claims) GetAudience() (jwt.ClaimStrings, error) {
+	return c.Audience, nil
+}
+
+// HasScope reports whether the claims were granted the provided scope.
+func (c *Claims) HasScope(scope string) bool {
+	return slices.Contains(c.Scopes, scope)
+}
+
+// HasRole reports whether the claims' subject was assigned the provided role.
+func (c *Claims) HasRole(role string) bool {
+	return slices.Contains(c.Roles, role)
+}
+
+// Expiration returns the claims' expiration as a [time.Time], or the zero-value if unset.
+func (c *Claims) Expiration() time.Time {
+	if c.ExpiresAt == nil {
+		return time.Time{}
+	}
+
+	return c.ExpiresAt.Time
+}
+
+// MarshalJSON flattens [Claims.Custom] into the top-level claims object. Registered keys always take precedence.
+func (c Claims) MarshalJSON() ([]byte, error) {
+	type alias Claims
+
+	registered, e := json.Marshal(alias(c))
+	if e != nil {
+		return nil, e
+	}
+
+	if len(c.Custom) == 0 {
+		return registered, nil
+	}
+
+	var mapping = make(map[string]interface{}, len(c.Custom))
+	for key, value := range c.Custom {
+		mapping[key] = value
+	}
+
+	if e := json.Unmarshal(registered, &mapping); e != nil {
+		return nil, e
+	}
+
+	return json.Marshal(mapping)
+}
+
+// UnmarshalJSON hydrates the registered claims, and collects all remaining keys into [Claims.Custom].
+func (c *Claims) UnmarshalJSON(data []byte) error {
+	type alias Claims
+
+	var target alias
+	if e := json.Unmarshal(data, &target); e != nil {
+		return e
+	}
+
+	var mapping map[string]interface{}
+	if e := json.Unmarshal(data, &mapping); e != nil {
+		return e
+	}
+
+	for _, key := range registered {
+		delete(mapping, key)
+	}
+
+	*c = Claims(target)
+	if len(mapping) > 0 {
+		c.Custom = mapping
+	}
+
+	return nil
+}
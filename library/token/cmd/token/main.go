@@ -0,0 +1,309 @@
+// Command token mints, inspects and verifies playground jwt(s) using [library/token]. Install from the module's root via
+// "go install ./cmd/token".
+//
+// Usage:
+//
+//	token keygen [--directory .secrets/jwt-ecdsa-pem] [--force]
+//	token mint   [--keys <directory>] --sub <subject> [--iss <issuer>] [--aud <audience>] [--scopes a,b] [--roles ROOT] [--ttl 1h] [--claim key=value]
+//	token decode [<jwt>]
+//	token verify [--keys <directory> | --jwks <url>] [--iss <issuer>] [--aud <audience>] [--scopes a,b] [<jwt>]
+//
+// Commands reading a jwt fall back to standard input when the argument is omitted, so tokens can be piped between
+// invocations, e.g.:
+//
+//	export TOKEN="$(token mint --sub user@example.com --roles ROOT)"
+//	curl --header "Authorization: Bearer ${TOKEN}" http://localhost:8080/v1/users/avatar
+package main
+
+import (
+	"bufio"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/fs"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"library/token"
+)
+
+// list is a repeatable, comma-delimited [flag.Value].
+type list []string
+
+func (l *list) String() string {
+	return strings.Join(*l, ",")
+}
+
+func (l *list) Set(value string) error {
+	for _, partial := range strings.Split(value, ",") {
+		if partial = strings.TrimSpace(partial); partial != "" {
+			*l = append(*l, partial)
+		}
+	}
+
+	return nil
+}
+
+var ctx, cancel = context.WithCancel(context.Background())
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "Usage: token <keygen|mint|decode|verify> [flags]")
+	fmt.Fprintln(os.Stderr, "Run \"token <command> --help\" for a command's flag(s).")
+}
+
+func main() {
+	defer cancel()
+
+	slog.SetLogLoggerLevel(slog.LevelWarn)
+
+	if len(os.Args) < 2 {
+		usage()
+		os.Exit(1)
+	}
+
+	var e error
+	switch command, arguments := os.Args[1], os.Args[2:]; command {
+	case "keygen":
+		e = keygen(arguments)
+	case "mint":
+		e = mint(arguments)
+	case "decode":
+		e = decode(arguments)
+	case "verify":
+		e = verify(arguments)
+	case "help", "-h", "--help":
+		usage()
+		return
+	default:
+		fmt.Fprintf(os.Stderr, "Unknown Command: %q\n", command)
+		usage()
+		os.Exit(1)
+	}
+
+	if e != nil {
+		fmt.Fprintf(os.Stderr, "Error: %s\n", e.Error())
+		os.Exit(1)
+	}
+}
+
+// keygen writes an ECDSA P-256 key pair using the secret volume mount's layout.
+func keygen(arguments []string) error {
+	set := flag.NewFlagSet("keygen", flag.ExitOnError)
+
+	directory := set.String("directory", filepath.Join(".secrets", filepath.Base(token.Mount)), "Output directory for ecdsa.private.pem and ecdsa.public.pem.")
+	force := set.Bool("force", false, "Overwrite an existing key pair.")
+
+	set.Parse(arguments)
+
+	private, e := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if e != nil {
+		return e
+	}
+
+	encoded, e := x509.MarshalECPrivateKey(private)
+	if e != nil {
+		return e
+	}
+
+	public, e := x509.MarshalPKIXPublicKey(&private.PublicKey)
+	if e != nil {
+		return e
+	}
+
+	if e := os.MkdirAll(*directory, 0o700); e != nil {
+		return e
+	}
+
+	files := []struct {
+		name  string
+		block *pem.Block
+		mode  os.FileMode
+	}{
+		{"ecdsa.private.pem", &pem.Block{Type: "EC PRIVATE KEY", Bytes: encoded}, 0o600},
+		{"ecdsa.public.pem", &pem.Block{Type: "PUBLIC KEY", Bytes: public}, 0o644},
+	}
+
+	for _, file := range files {
+		path := filepath.Join(*directory, file.name)
+		if _, e := os.Stat(path); e == nil && !(*force) {
+			return fmt.Errorf("%s already exists - use --force to overwrite", path)
+		} else if e != nil && !(errors.Is(e, fs.ErrNotExist)) {
+			return e
+		}
+
+		if e := os.WriteFile(path, pem.EncodeToMemory(file.block), file.mode); e != nil {
+			return e
+		}
+
+		fmt.Fprintln(os.Stderr, "Wrote", path)
+	}
+
+	fmt.Println(token.Thumbprint(&private.PublicKey))
+
+	return nil
+}
+
+// mint signs a new jwt, writing it to standard output.
+func mint(arguments []string) error {
+	set := flag.NewFlagSet("mint", flag.ExitOnError)
+
+	var audience, scopes, roles, custom list
+
+	keys := set.String("keys", token.Mount, "Directory containing ecdsa.private.pem.")
+	subject := set.String("sub", "", "(Required) Subject claim - typically the user's email address.")
+	issuer := set.String("iss", "", "Issuer claim.")
+	ttl := set.Duration("ttl", time.Hour, "Token lifetime.")
+
+	set.Var(&audience, "aud", "Audience claim(s). Repeatable or comma-delimited.")
+	set.Var(&scopes, "scopes", "Scope claim(s). Repeatable or comma-delimited.")
+	set.Var(&roles, "roles", "Role claim(s), e.g. ROOT. Repeatable or comma-delimited.")
+	set.Var(&custom, "claim", "Custom key=value claim(s). Values are parsed as JSON when valid, otherwise as strings.")
+
+	set.Parse(arguments)
+
+	if *subject == "" {
+		return errors.New("flag --sub is required")
+	}
+
+	if e := token.Load(*keys); e != nil {
+		return fmt.Errorf("unable to load keys from %s: %w", *keys, e)
+	}
+
+	claims := &token.Claims{Subject: *subject, Issuer: *issuer, Audience: []string(audience), Scopes: scopes, Roles: roles}
+	for _, pair := range custom {
+		k, v, ok := strings.Cut(pair, "=")
+		if !(ok) || k == "" {
+			return fmt.Errorf("invalid --claim %q - expected key=value", pair)
+		}
+
+		if claims.Custom == nil {
+			claims.Custom = make(map[string]interface{})
+		}
+
+		var value interface{}
+		if e := json.Unmarshal([]byte(v), &value); e != nil {
+			value = v
+		}
+
+		claims.Custom[k] = value
+	}
+
+	signed, e := token.Create(ctx, claims, token.TTL(*ttl))
+	if e != nil {
+		return e
+	}
+
+	fmt.Println(signed)
+
+	return nil
+}
+
+// decode pretty-prints a jwt's header and claims without verifying its signature.
+func decode(arguments []string) error {
+	set := flag.NewFlagSet("decode", flag.ExitOnError)
+	set.Parse(arguments)
+
+	raw, e := input(set.Args())
+	if e != nil {
+		return e
+	}
+
+	partials := strings.Split(raw, ".")
+	if len(partials) != 3 {
+		return errors.New("malformed jwt - expected three dot-delimited segments")
+	}
+
+	output := make(map[string]json.RawMessage, 2)
+	for index, name := range []string{"header", "claims"} {
+		segment, e := base64.RawURLEncoding.DecodeString(partials[index])
+		if e != nil {
+			return fmt.Errorf("unable to decode jwt %s: %w", name, e)
+		}
+
+		if !(json.Valid(segment)) {
+			return fmt.Errorf("jwt %s is not valid json", name)
+		}
+
+		output[name] = segment
+	}
+
+	return emit(output)
+}
+
+// verify checks a jwt against a local public key or a remote jwks, printing its claims on success.
+func verify(arguments []string) error {
+	set := flag.NewFlagSet("verify", flag.ExitOnError)
+
+	var audience, scopes list
+
+	keys := set.String("keys", token.Mount, "Directory containing ecdsa.public.pem. Ignored when --jwks is set.")
+	jwks := set.String("jwks", "", "JWKS url to select the verification key from.")
+	issuer := set.String("iss", "", "Required issuer claim.")
+	leeway := set.Duration("leeway", 30*time.Second, "Clock-skew leeway.")
+
+	set.Var(&audience, "aud", "Accepted audience claim(s). Repeatable or comma-delimited.")
+	set.Var(&scopes, "scopes", "Required scope claim(s). Repeatable or comma-delimited.")
+
+	set.Parse(arguments)
+
+	raw, e := input(set.Args())
+	if e != nil {
+		return e
+	}
+
+	settings := []token.Variadic{token.Issuer(*issuer), token.Audience(audience...), token.Scopes(scopes...), token.Leeway(*leeway)}
+	if *jwks != "" {
+		keyset, e := token.Fetch(ctx, *jwks)
+		if e != nil {
+			return fmt.Errorf("unable to fetch jwks: %w", e)
+		}
+
+		settings = append(settings, token.Keyset(keyset))
+	} else if e := token.Load(*keys); e != nil {
+		return fmt.Errorf("unable to load keys from %s: %w", *keys, e)
+	}
+
+	verified, e := token.Verify(ctx, raw, settings...)
+	if e != nil {
+		return e
+	}
+
+	return emit(map[string]interface{}{"valid": true, "header": verified.Header, "claims": verified.Claims})
+}
+
+// input returns the jwt from the first positional argument, otherwise from standard input.
+func input(arguments []string) (string, error) {
+	if len(arguments) > 0 {
+		return strings.TrimSpace(arguments[0]), nil
+	}
+
+	value, e := bufio.NewReader(os.Stdin).ReadString('\n')
+	if e != nil && !(errors.Is(e, io.EOF)) {
+		return "", e
+	}
+
+	if value = strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(value), "Bearer ")); value == "" {
+		return "", errors.New("a jwt argument or standard input is required")
+	}
+
+	return value, nil
+}
+
+func emit(value interface{}) error {
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "    ")
+
+	return encoder.Encode(value)
+}
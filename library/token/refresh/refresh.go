@@ -0,0 +1,190 @@
+package refresh
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+
+	"library/token"
+)
+
+// Options is the configuration structure optionally mutated via the [Variadic] constructor used throughout the package.
+type Options struct {
+	TTL    time.Duration    // TTL represents the lifetime of a refresh token. Defaults to 30 days.
+	Access []token.Variadic // Access represents the [token.Create] setting(s) used when minting access tokens.
+
+	Denylist token.Denylist // Denylist, if non-nil, additionally revokes the caller's access token upon [Manager.RevokeHandler].
+}
+
+// Variadic represents a functional constructor for the [Options] type. Typical callers of Variadic won't need to perform
+// nil checks as all implementations first construct an [Options] reference using packaged default(s).
+type Variadic func(o *Options)
+
+// options represents a default constructor.
+func options() *Options {
+	return &Options{
+		TTL: 30 * 24 * time.Hour,
+	}
+}
+
+// TTL sets [Options.TTL].
+func TTL(duration time.Duration) Variadic {
+	return func(o *Options) {
+		o.TTL = duration
+	}
+}
+
+// Access appends to [Options.Access].
+func Access(settings ...token.Variadic) Variadic {
+	return func(o *Options) {
+		o.Access = append(o.Access, settings...)
+	}
+}
+
+// Denylist sets [Options.Denylist].
+func Denylist(denylist token.Denylist) Variadic {
+	return func(o *Options) {
+		o.Denylist = denylist
+	}
+}
+
+// Pair represents an access + refresh token pair returned to the client.
+type Pair struct {
+	Access     string    `json:"access-token"`
+	Refresh    string    `json:"refresh-token"`
+	Type       string    `json:"token-type"`
+	Expiration time.Time `json:"expiration"` // Expiration represents the access token's expiration.
+}
+
+// Manager issues, rotates and revokes refresh tokens against a [Store].
+type Manager struct {
+	store   Store
+	options *Options
+}
+
+// New constructs a [Manager].
+func New(store Store, settings ...Variadic) *Manager {
+	o := options()
+	for _, option := range settings {
+		option(o)
+	}
+
+	return &Manager{store: store, options: o}
+}
+
+// Issue starts a new token family for the provided claims, returning the first access + refresh token pair.
+func (m *Manager) Issue(ctx context.Context, claims *token.Claims) (*Pair, error) {
+	return m.issue(ctx, claims, random())
+}
+
+// Refresh consumes the opaque refresh token and rotates it, returning a new pair within the same family. If the refresh token
+// was previously consumed, the entire family is revoked and [ErrReused] is returned.
+func (m *Manager) Refresh(ctx context.Context, opaque string) (*Pair, error) {
+	record, e := m.store.Consume(ctx, digest(opaque))
+	switch {
+	case errors.Is(e, ErrReused):
+		slog.WarnContext(ctx, "Refresh Token Reuse Detected - Revoking Token Family", slog.String("family", record.Family), slog.String("subject", record.Claims.Subject))
+
+		if e := m.store.Revoke(ctx, record.Family, time.Now().Add(m.options.TTL)); e != nil {
+			slog.ErrorContext(ctx, "Unable to Revoke Refresh Token Family", slog.String("family", record.Family), slog.String("error", e.Error()))
+			return nil, errors.Join(ErrReused, e)
+		}
+
+		return nil, ErrReused
+	case e != nil:
+		slog.WarnContext(ctx, "Unable to Consume Refresh Token", slog.String("error", e.Error()))
+		return nil, e
+	}
+
+	return m.issue(ctx, record.Claims, record.Family)
+}
+
+// Revoke invalidates the opaque refresh token's entire family. Revoking an unknown or expired token is not an error.
+func (m *Manager) Revoke(ctx context.Context, opaque string) error {
+	record, e := m.store.Consume(ctx, digest(opaque))
+	switch {
+	case errors.Is(e, ErrNotFound), errors.Is(e, ErrExpired), errors.Is(e, ErrRevoked):
+		return nil
+	case e != nil && !(errors.Is(e, ErrReused)):
+		return e
+	}
+
+	return m.store.Revoke(ctx, record.Family, time.Now().Add(m.options.TTL))
+}
+
+// RevokeSubject invalidates every token family previously issued to the subject - e.g. upon the account's deletion, so
+// its outstanding refresh token(s) can't mint access tokens issued after a [token.RevokeSubject] cutoff.
+func (m *Manager) RevokeSubject(ctx context.Context, subject string) error {
+	if subject == "" {
+		return token.ErrMissingSubject
+	}
+
+	return m.store.RevokeSubject(ctx, subject, time.Now().Add(m.options.TTL))
+}
+
+// Deny revokes the access token's identifier via [Options.Denylist], so it's rejected prior to its expiration. Token(s)
+// failing verification are already unusable, and are ignored.
+func (m *Manager) Deny(ctx context.Context, access string) error {
+	if m.options.Denylist == nil {
+		return nil
+	}
+
+	verified, e := token.Verify(ctx, access, m.options.Access...)
+	if e != nil {
+		return nil
+	}
+
+	return token.Revoke(ctx, m.options.Denylist, verified.Claims.(*token.Claims), m.options.Access...)
+}
+
+func (m *Manager) issue(ctx context.Context, claims *token.Claims, family string) (*Pair, error) {
+	// --> the template is stored without time-based claims so each rotation mints a fresh access token
+	var template = *claims
+	template.IssuedAt, template.NotBefore, template.ExpiresAt, template.ID = nil, nil, nil, ""
+
+	access, e := token.Create(ctx, &template, m.options.Access...)
+	if e != nil {
+		return nil, e
+	}
+
+	opaque := random()
+	now := time.Now()
+
+	record := &Record{Digest: digest(opaque), Family: family, Claims: &template, Creation: now, Expiration: now.Add(m.options.TTL)}
+	if e := m.store.Save(ctx, record); e != nil {
+		slog.ErrorContext(ctx, "Unable to Save Refresh Token", slog.String("error", e.Error()))
+		return nil, e
+	}
+
+	// --> the access token was signed above; parsing without verification is only used to surface its expiration
+	var minted token.Claims
+	if _, _, e := jwt.NewParser().ParseUnverified(access, &minted); e != nil {
+		return nil, e
+	}
+
+	return &Pair{Access: access, Refresh: opaque, Type: "Bearer", Expiration: minted.Expiration()}, nil
+}
+
+// random generates a 256-bit, url-safe opaque identifier.
+func random() string {
+	var buffer = make([]byte, 32)
+	if _, e := rand.Read(buffer); e != nil {
+		panic(e)
+	}
+
+	return base64.RawURLEncoding.EncodeToString(buffer)
+}
+
+// digest returns the hex-encoded sha256 digest of the opaque refresh token.
+func digest(opaque string) string {
+	sum := sha256.Sum256([]byte(opaque))
+
+	return hex.EncodeToString(sum[:])
+}
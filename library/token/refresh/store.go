@@ -0,0 +1,42 @@
+package refresh
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"library/token"
+)
+
+var (
+	ErrNotFound = errors.New("refresh token not found")
+	ErrExpired  = errors.New("refresh token has expired")
+	ErrRevoked  = errors.New("refresh token has been revoked")
+	ErrReused   = errors.New("refresh token reuse detected")
+)
+
+// Record represents the server-side state of a single refresh token. The opaque token string is never persisted - only its digest.
+type Record struct {
+	Digest     string        `json:"digest"`     // Digest is the hex-encoded sha256 digest of the opaque refresh token.
+	Family     string        `json:"family"`     // Family groups every refresh token rotated from the same original login.
+	Claims     *token.Claims `json:"claims"`     // Claims is the template used to mint access tokens during rotation.
+	Creation   time.Time     `json:"creation"`   // Creation is the time the record was issued.
+	Expiration time.Time     `json:"expiration"` // Expiration is the time after which the record can no longer be used.
+}
+
+// Store is the pluggable persistence layer for refresh token [Record] values.
+type Store interface {
+	// Save persists a new record. The store may discard the record once its [Record.Expiration] has passed.
+	Save(ctx context.Context, record *Record) error
+
+	// Consume atomically marks the record as used and returns it. A record that has already been consumed returns
+	// the record alongside [ErrReused]; a record belonging to a revoked family returns [ErrRevoked].
+	Consume(ctx context.Context, digest string) (*Record, error)
+
+	// Revoke invalidates every record belonging to the family, including records not yet consumed.
+	Revoke(ctx context.Context, family string, expiration time.Time) error
+
+	// RevokeSubject invalidates every family issued to the (case-insensitive) subject prior to the call, as if each were
+	// passed to Revoke. Families issued afterward are unaffected.
+	RevokeSubject(ctx context.Context, subject string, expiration time.Time) error
+}
@@ -0,0 +1,94 @@
+package revocation
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"library/token"
+)
+
+// subjects namespaces all redis key(s) written by the redis-backed [token.Cutoffs].
+const subjects = "revoked-subject"
+
+// entry represents a subject's cutoff and the instant it may be forgotten.
+type entry struct {
+	cutoff     time.Time
+	expiration time.Time
+}
+
+// cutoffs is an in-process [token.Cutoffs] implementation. Suitable for tests and single-replica local development.
+type cutoffs struct {
+	mutex    sync.RWMutex
+	subjects map[string]entry
+}
+
+// MemoryCutoffs constructs an in-process [token.Cutoffs].
+func MemoryCutoffs() token.Cutoffs {
+	return &cutoffs{
+		subjects: make(map[string]entry),
+	}
+}
+
+func (c *cutoffs) Cut(ctx context.Context, subject string, instant time.Time, ttl time.Duration) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	now := time.Now()
+	for key, value := range c.subjects {
+		if now.After(value.expiration) {
+			delete(c.subjects, key)
+		}
+	}
+
+	c.subjects[strings.ToLower(subject)] = entry{cutoff: instant, expiration: now.Add(ttl)}
+
+	return nil
+}
+
+func (c *cutoffs) Cutoff(ctx context.Context, subject string) (time.Time, error) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	value, ok := c.subjects[strings.ToLower(subject)]
+	if !(ok) || time.Now().After(value.expiration) {
+		return time.Time{}, nil
+	}
+
+	return value.cutoff, nil
+}
+
+// cutoffcache is a redis-backed [token.Cutoffs] implementation. Each subject's cutoff is written as unix nanoseconds, with
+// a TTL covering the lifetime of any token issued before it.
+type cutoffcache struct {
+	client redis.UniversalClient
+}
+
+// RedisCutoffs constructs a redis-backed [token.Cutoffs] from an existing client.
+func RedisCutoffs(client redis.UniversalClient) token.Cutoffs {
+	return &cutoffcache{client: client}
+}
+
+func (c *cutoffcache) Cut(ctx context.Context, subject string, instant time.Time, ttl time.Duration) error {
+	return c.client.Set(ctx, key(subject), instant.UnixNano(), ttl).Err()
+}
+
+func (c *cutoffcache) Cutoff(ctx context.Context, subject string) (time.Time, error) {
+	nanoseconds, e := c.client.Get(ctx, key(subject)).Int64()
+	if errors.Is(e, redis.Nil) {
+		return time.Time{}, nil
+	} else if e != nil {
+		return time.Time{}, e
+	}
+
+	return time.Unix(0, nanoseconds), nil
+}
+
+// key returns the subject's redis key. Subjects are email addresses, compared case-insensitively.
+func key(subject string) string {
+	return subjects + ":" + strings.ToLower(subject)
+}
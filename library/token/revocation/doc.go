@@ -0,0 +1,3 @@
+// Package revocation provides [token.Denylist] implementation(s) for revoking individual token(s) by identifier, and
+// [token.Cutoffs] implementation(s) for revoking all of a subject's token(s) at once.
+package revocation
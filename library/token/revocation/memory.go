@@ -0,0 +1,64 @@
+package revocation
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"library/token"
+)
+
+// memory is an in-process [token.Denylist] implementation. Suitable for tests and single-replica local development.
+type memory struct {
+	mutex       sync.RWMutex
+	identifiers map[string]time.Time
+}
+
+// Memory constructs an in-process [token.Denylist].
+func Memory() token.Denylist {
+	return &memory{
+		identifiers: make(map[string]time.Time),
+	}
+}
+
+func (m *memory) Revoke(ctx context.Context, id string, expiration time.Time) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	now := time.Now()
+	for identifier, value := range m.identifiers {
+		if now.After(value) {
+			delete(m.identifiers, identifier)
+		}
+	}
+
+	m.identifiers[id] = expiration
+
+	return nil
+}
+
+func (m *memory) Revoked(ctx context.Context, id string) (bool, error) {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	expiration, ok := m.identifiers[id]
+
+	return ok && time.Now().Before(expiration), nil
+}
+
+// List implements [Lister].
+func (m *memory) List(ctx context.Context) ([]string, error) {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	now := time.Now()
+
+	var identifiers = make([]string, 0, len(m.identifiers))
+	for identifier, expiration := range m.identifiers {
+		if now.Before(expiration) {
+			identifiers = append(identifiers, identifier)
+		}
+	}
+
+	return identifiers, nil
+}
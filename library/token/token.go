@@ -3,157 +3,327 @@ package token
 import (
 	"context"
 	"crypto/ecdsa"
+	"crypto/rand"
 	"crypto/x509"
+	"encoding/hex"
 	"encoding/pem"
 	"errors"
 	"io/fs"
 	"log/slog"
 	"os"
 	"path/filepath"
+	"slices"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
 )
 
-var h []byte
+// Mount is the secret volume mount holding the "ecdsa.private.pem" and "ecdsa.public.pem" key pair.
+const Mount = "/etc/secrets/jwt-ecdsa-pem"
+
 var key *ecdsa.PrivateKey
 var pkey *ecdsa.PublicKey
 
-// decode ...
+// Decode parses the pem-encoded key pair. Either argument may be nil, in which case its return value is nil.
 // private argument must be a private pem-encoded key
 // public argument must be a public pem-encoded key
-func decode(private, public []byte) (*ecdsa.PrivateKey, *ecdsa.PublicKey) {
-	block, _ := pem.Decode(private)
-	x509encoded := block.Bytes
-	pkey, _ := x509.ParseECPrivateKey(x509encoded)
+func Decode(private, public []byte) (*ecdsa.PrivateKey, *ecdsa.PublicKey, error) {
+	var pemprivate *ecdsa.PrivateKey
+	if private != nil {
+		block, _ := pem.Decode(private)
+		if block == nil {
+			return nil, nil, ErrInvalidKey
+		}
 
-	publicblock, _ := pem.Decode(public)
-	x509encodedpublic := publicblock.Bytes
-	genericpublickey, _ := x509.ParsePKIXPublicKey(x509encodedpublic)
-	pubkey := genericpublickey.(*ecdsa.PublicKey)
+		value, e := x509.ParseECPrivateKey(block.Bytes)
+		if e != nil {
+			return nil, nil, errors.Join(ErrInvalidKey, e)
+		}
 
-	return pkey, pubkey
-}
+		pemprivate = value
+	}
 
-func init() {
-	// secret, e := os.ReadFile("/etc/secrets/jwt-ecdsa-pem")
-	// if e != nil {
-	// 	slog.Error("Unable to Read Secret from Volume Mount", slog.String("path", "/etc/secrets/jwt-ecdsa-pem"), slog.String("error", e.Error()))
-	//
-	// 	panic(e)
-	// }
+	var pempublic *ecdsa.PublicKey
+	if public != nil {
+		block, _ := pem.Decode(public)
+		if block == nil {
+			return nil, nil, ErrInvalidKey
+		}
 
-	var configurations = make(map[string][]byte)
+		generic, e := x509.ParsePKIXPublicKey(block.Bytes)
+		if e != nil {
+			return nil, nil, errors.Join(ErrInvalidKey, e)
+		}
 
-	e := filepath.WalkDir("/etc/secrets/jwt-ecdsa-pem", func(path string, d fs.DirEntry, err error) error {
-		if err != nil {
-			return err
+		value, ok := generic.(*ecdsa.PublicKey)
+		if !(ok) {
+			return nil, nil, ErrInvalidKey
 		}
 
-		if !(d.IsDir()) && d.Type().IsRegular() {
-			value, e := os.ReadFile(path)
-			if e != nil {
-				return e
-			}
+		pempublic = value
+	}
 
-			configurations[d.Name()] = value
-		}
+	return pemprivate, pempublic, nil
+}
 
-		return nil
-	})
+// Configure sets the package's signing and verification key(s). If public is nil, it's derived from private. Configure
+// must be called prior to concurrent use of [Create] or [Verify].
+func Configure(private *ecdsa.PrivateKey, public *ecdsa.PublicKey) {
+	if public == nil && private != nil {
+		public = &private.PublicKey
+	}
+
+	key = private
+	pkey = public
+}
+
+// Load reads the key pair from a directory using the secret volume mount's layout (see [Mount]). Either file may be absent,
+// but not both.
+func Load(directory string) error {
+	private, e := os.ReadFile(filepath.Join(directory, "ecdsa.private.pem"))
+	if e != nil && !(errors.Is(e, fs.ErrNotExist)) {
+		return e
+	}
+
+	public, e := os.ReadFile(filepath.Join(directory, "ecdsa.public.pem"))
+	if e != nil && !(errors.Is(e, fs.ErrNotExist)) {
+		return e
+	}
 
+	if private == nil && public == nil {
+		return ErrMissingKey
+	}
+
+	pemprivate, pempublic, e := Decode(private, public)
 	if e != nil {
-		slog.Error("Error Walking Secrets Volume Mount", slog.String("error", e.Error()))
-		panic(e)
+		return e
 	}
 
-	e = filepath.WalkDir("/etc/secrets/jwt-signing-token", func(path string, d fs.DirEntry, err error) error {
-		if err != nil {
-			return err
-		}
+	Configure(pemprivate, pempublic)
+
+	return nil
+}
+
+func init() {
+	var configurations = make(map[string][]byte)
 
-		if !(d.IsDir()) && d.Type().IsRegular() {
-			value, e := os.ReadFile(path)
-			if e != nil {
-				return e
+	for _, directory := range []string{Mount, "/etc/secrets/jwt-signing-token"} {
+		e := filepath.WalkDir(directory, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
 			}
 
-			configurations[d.Name()] = value
+			if !(d.IsDir()) && d.Type().IsRegular() {
+				value, e := os.ReadFile(path)
+				if e != nil {
+					return e
+				}
+
+				configurations[d.Name()] = value
+			}
+
+			return nil
+		})
+
+		if errors.Is(e, fs.ErrNotExist) {
+			// --> outside of the cluster (e.g. tests, cli tooling), keys are established via Configure or Load
+			slog.Debug("Secrets Volume Mount Not Found", slog.String("path", directory))
+			continue
+		} else if e != nil {
+			slog.Error("Error Walking Secrets Volume Mount", slog.String("error", e.Error()))
+			panic(e)
 		}
+	}
 
-		return nil
-	})
+	for key := range configurations {
+		slog.Debug("Secret", slog.String("key", key))
+	}
 
-	if e != nil {
-		slog.Error("Error Walking Secrets Volume Mount", slog.String("error", e.Error()))
-		panic(e)
+	if configurations["ecdsa.private.pem"] != nil || configurations["ecdsa.public.pem"] != nil {
+		pemprivate, pempublic, e := Decode(configurations["ecdsa.private.pem"], configurations["ecdsa.public.pem"])
+		if e != nil {
+			slog.Error("Unable to Decode ECDSA Key Pair", slog.String("error", e.Error()))
+			panic(e)
+		}
+
+		Configure(pemprivate, pempublic)
 	}
+}
 
-	for key, bytes := range configurations {
-		slog.Info("Secret", slog.String("key", key), slog.String("value", string(bytes)))
+// Create signs the provided [Claims] using the ECDSA private key. [Claims.IssuedAt] and [Claims.NotBefore] default to now,
+// [Claims.ExpiresAt] defaults to now + [Options.TTL], and [Claims.ID] is populated with a random identifier when empty.
+func Create(ctx context.Context, claims *Claims, settings ...Variadic) (string, error) {
+	o := options()
+	for _, option := range settings {
+		option(o)
 	}
 
-	pemprivate, pempublic := decode(configurations["ecdsa.private.pem"], configurations["ecdsa.public.pem"])
+	var c = *claims
 
-	h = configurations["jwt-signing-token"]
+	now := time.Now()
+	if c.IssuedAt == nil {
+		c.IssuedAt = jwt.NewNumericDate(now)
+	}
 
-	key = pemprivate
-	pkey = pempublic
-}
+	if c.NotBefore == nil {
+		c.NotBefore = jwt.NewNumericDate(now)
+	}
 
-func Create(ctx context.Context, token *jwt.Token) (string, error) {
-	hmactoken := jwt.New(jwt.SigningMethodHS256)
-	hmacclaims := hmactoken.Claims.(jwt.MapClaims)
-	for key, element := range token.Claims.(jwt.MapClaims) {
-		hmacclaims[key] = element
+	if c.ExpiresAt == nil {
+		c.ExpiresAt = jwt.NewNumericDate(now.Add(o.TTL))
 	}
 
-	expiration := time.Now().Add(time.Hour * 1).Unix()
-	hmacclaims["exp"] = expiration
+	if c.ID == "" {
+		c.ID = identifier()
+	}
+
+	if key == nil {
+		return "", ErrMissingKey
+	}
 
-	hmactokenstring, e := hmactoken.SignedString(h)
+	jwttoken := jwt.NewWithClaims(jwt.SigningMethodES256, &c)
+	jwttoken.Header["kid"] = Thumbprint(&key.PublicKey)
+
+	signed, e := jwttoken.SignedString(key)
 	if e != nil {
-		slog.WarnContext(ctx, "Error Signing JWT Token", slog.Any("token", token), slog.String("error", e.Error()))
+		slog.WarnContext(ctx, "Error Signing JWT Token", slog.Any("claims", c), slog.String("error", e.Error()))
 
 		return "", e
 	}
 
-	return hmactokenstring, nil
+	return signed, nil
 }
 
-func Verify(ctx context.Context, t string) (*jwt.Token, error) {
-	token, e := jwt.Parse(t, func(token *jwt.Token) (interface{}, error) {
-		v, ok := token.Method.(*jwt.SigningMethodECDSA)
-		if !ok {
+// Verify parses and verifies the jwt string. Upon success, the returned [jwt.Token.Claims] is always of type *[Claims]. Requirement(s)
+// established via the settings argument return a *[ClaimError] if unmet.
+func Verify(ctx context.Context, t string, settings ...Variadic) (*jwt.Token, error) {
+	o := options()
+	for _, option := range settings {
+		option(o)
+	}
+
+	parser := jwt.NewParser(jwt.WithValidMethods([]string{jwt.SigningMethodES256.Alg(), jwt.SigningMethodES384.Alg(), jwt.SigningMethodES512.Alg()}), jwt.WithLeeway(o.Leeway), jwt.WithIssuedAt(), jwt.WithExpirationRequired())
+
+	token, e := parser.ParseWithClaims(t, &Claims{}, func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodECDSA); !ok {
 			return nil, jwt.ErrTokenSignatureInvalid
 		}
 
-		_ = v
+		switch {
+		case o.Keyset != nil:
+			kid, _ := token.Header["kid"].(string)
 
-		return pkey, nil
-	})
+			return o.Keyset.Key(kid)
+		case o.Public != nil:
+			return o.Public, nil
+		case pkey != nil:
+			return pkey, nil
+		}
 
-	if e != nil {
-		slog.WarnContext(ctx, "Error Parsing JWT Token", slog.String("error", e.Error()))
-		return nil, e
-	}
+		return nil, ErrMissingKey
+	})
 
 	switch {
-	case token.Valid:
+	case e == nil && token.Valid:
 		slog.DebugContext(ctx, "Verified Valid Token", slog.Any("token", token))
-		return token, nil
 	case errors.Is(e, jwt.ErrTokenMalformed):
 		slog.WarnContext(ctx, "Unable to Verify Malformed String as JWT Token", slog.String("error", e.Error()))
+		return nil, e
 	case errors.Is(e, jwt.ErrTokenSignatureInvalid):
 		// Invalid signature
-		slog.WarnContext(ctx, "Invalid JWT Signature", slog.Any("token", token), slog.String("error", e.Error()))
+		slog.WarnContext(ctx, "Invalid JWT Signature", slog.String("error", e.Error()))
+		return nil, e
 	case errors.Is(e, jwt.ErrTokenExpired):
-		slog.WarnContext(ctx, "Expired JWT Token", slog.Any("token", token), slog.String("error", e.Error()))
+		slog.WarnContext(ctx, "Expired JWT Token", slog.String("error", e.Error()))
+		return nil, e
 	case errors.Is(e, jwt.ErrTokenNotValidYet):
-		slog.WarnContext(ctx, "Received a Future, Valid JWT Token", slog.Any("token", token), slog.String("error", e.Error()))
+		slog.WarnContext(ctx, "Received a Future, Valid JWT Token", slog.String("error", e.Error()))
+		return nil, e
+	case e != nil:
+		slog.ErrorContext(ctx, "Unknown Error While Attempting to Validate JWT Token", slog.String("error", e.Error()))
+		return nil, e
 	default:
-		slog.ErrorContext(ctx, "Unknown Error While Attempting to Validate JWT Token", slog.Any("token", token), slog.String("error", e.Error()))
+		slog.ErrorContext(ctx, "Unknown Error While Attempting to Validate JWT Token")
+		return nil, jwt.ErrTokenUnverifiable
+	}
+
+	claims := token.Claims.(*Claims)
+	if e := validate(claims, o); e != nil {
+		slog.WarnContext(ctx, "JWT Token Failed Claim Requirement(s)", slog.String("error", e.Error()))
+		return nil, e
+	}
+
+	if o.Denylist != nil {
+		revoked, e := o.Denylist.Revoked(ctx, claims.ID)
+		if e != nil {
+			slog.ErrorContext(ctx, "Unable to Check JWT Token Revocation Status", slog.String("jti", claims.ID), slog.String("error", e.Error()))
+			return nil, e
+		} else if revoked {
+			slog.WarnContext(ctx, "Revoked JWT Token", slog.String("jti", claims.ID), slog.String("subject", claims.Subject))
+			return nil, ErrRevoked
+		}
+	}
+
+	if o.Cutoffs != nil {
+		cutoff, e := o.Cutoffs.Cutoff(ctx, claims.Subject)
+		if e != nil {
+			slog.ErrorContext(ctx, "Unable to Check JWT Token Subject Revocation Status", slog.String("subject", claims.Subject), slog.String("error", e.Error()))
+			return nil, e
+		} else if !(cutoff.IsZero()) && (claims.IssuedAt == nil || !(claims.IssuedAt.After(cutoff))) {
+			// --> "iat" has second precision; a token issued within the cutoff's second is conservatively rejected
+			slog.WarnContext(ctx, "Revoked JWT Token Subject", slog.String("jti", claims.ID), slog.String("subject", claims.Subject))
+			return nil, ErrRevoked
+		}
+	}
+
+	return token, nil
+}
+
+// Verification returns a [Verify] function closure bound to the provided settings. The signature is compatible with authentication
+// middleware expecting a func(ctx context.Context, token string) (*jwt.Token, error) verification function.
+func Verification(settings ...Variadic) func(ctx context.Context, t string) (*jwt.Token, error) {
+	return func(ctx context.Context, t string) (*jwt.Token, error) {
+		return Verify(ctx, t, settings...)
+	}
+}
+
+// validate enforces the [Options] requirement(s) against already signature-verified claims.
+func validate(claims *Claims, o *Options) error {
+	if o.Subject && claims.Subject == "" {
+		return &ClaimError{Claim: "sub", Expected: "non-empty", Actual: claims.Subject, Err: ErrMissingSubject}
+	}
+
+	if o.Issuer != "" && claims.Issuer != o.Issuer {
+		return &ClaimError{Claim: "iss", Expected: o.Issuer, Actual: claims.Issuer, Err: ErrInvalidIssuer}
+	}
+
+	if len(o.Audience) > 0 && !slices.ContainsFunc(o.Audience, func(audience string) bool { return slices.Contains(claims.Audience, audience) }) {
+		return &ClaimError{Claim: "aud", Expected: o.Audience, Actual: []string(claims.Audience), Err: ErrInvalidAudience}
+	}
+
+	if o.Denylist != nil && claims.ID == "" {
+		return &ClaimError{Claim: "jti", Expected: "non-empty", Actual: claims.ID, Err: ErrMissingID}
+	}
+
+	for _, scope := range o.Scopes {
+		if !(claims.HasScope(scope)) {
+			return &ClaimError{Claim: "scopes", Expected: o.Scopes, Actual: claims.Scopes, Err: ErrInsufficientScope}
+		}
+	}
+
+	if len(o.Roles) > 0 && !slices.ContainsFunc(o.Roles, claims.HasRole) {
+		return &ClaimError{Claim: "roles", Expected: o.Roles, Actual: claims.Roles, Err: ErrInsufficientRole}
+	}
+
+	return nil
+}
+
+// identifier generates a random, 128-bit hex-encoded "jti" claim value.
+func identifier() string {
+	var buffer = make([]byte, 16)
+	if _, e := rand.Read(buffer); e != nil {
+		panic(e)
 	}
 
-	return nil, e
+	return hex.EncodeToString(buffer)
 }
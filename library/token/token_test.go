@@ -0,0 +1,149 @@
+package token
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+func configure(t *testing.T) *ecdsa.PrivateKey {
+	t.Helper()
+
+	private, e := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if e != nil {
+		t.Fatal(e)
+	}
+
+	Configure(private, nil)
+
+	return private
+}
+
+func TestCreateVerify(t *testing.T) {
+	configure(t)
+
+	ctx := context.Background()
+
+	claims := &Claims{Subject: "user@example.com", Issuer: "user-service", Audience: []string{"api"}, Scopes: []string{"read"}, Roles: []string{"ROOT"}, Custom: map[string]interface{}{"tenant": "playground"}}
+
+	signed, e := Create(ctx, claims, TTL(time.Minute))
+	if e != nil {
+		t.Fatalf("unexpected error creating token: %v", e)
+	}
+
+	verified, e := Verify(ctx, signed, Issuer("user-service"), Audience("other", "api"), Scopes("read"), Roles("ROOT"))
+	if e != nil {
+		t.Fatalf("unexpected error verifying token: %v", e)
+	}
+
+	result := verified.Claims.(*Claims)
+	if result.Subject != claims.Subject || result.ID == "" || result.Custom["tenant"] != "playground" {
+		t.Errorf("unexpected claims: %+v", result)
+	}
+
+	if lifetime := result.Expiration().Sub(result.IssuedAt.Time); lifetime != time.Minute {
+		t.Errorf("expected a one minute lifetime, got %s", lifetime)
+	}
+}
+
+func TestVerifyRequirements(t *testing.T) {
+	configure(t)
+
+	ctx := context.Background()
+
+	signed, e := Create(ctx, &Claims{Subject: "user@example.com", Issuer: "user-service", Audience: []string{"api"}, Scopes: []string{"read"}})
+	if e != nil {
+		t.Fatal(e)
+	}
+
+	tests := []struct {
+		name     string
+		settings []Variadic
+		claim    string
+		expected error
+	}{
+		{"Issuer", []Variadic{Issuer("authentication-service")}, "iss", ErrInvalidIssuer},
+		{"Audience", []Variadic{Audience("other")}, "aud", ErrInvalidAudience},
+		{"Scopes", []Variadic{Scopes("read", "write")}, "scopes", ErrInsufficientScope},
+		{"Roles", []Variadic{Roles("ROOT")}, "roles", ErrInsufficientRole},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, e := Verify(ctx, signed, test.settings...)
+			if !(errors.Is(e, test.expected)) {
+				t.Fatalf("expected %v, got %v", test.expected, e)
+			}
+
+			var claim *ClaimError
+			if !(errors.As(e, &claim)) {
+				t.Fatalf("expected a *ClaimError, got %T", e)
+			}
+
+			if claim.Claim != test.claim {
+				t.Errorf("expected claim %q, got %q", test.claim, claim.Claim)
+			}
+		})
+	}
+}
+
+func TestVerifyMissingSubject(t *testing.T) {
+	configure(t)
+
+	ctx := context.Background()
+
+	signed, e := Create(ctx, &Claims{Issuer: "user-service"})
+	if e != nil {
+		t.Fatal(e)
+	}
+
+	if _, e := Verify(ctx, signed); !(errors.Is(e, ErrMissingSubject)) {
+		t.Errorf("expected %v, got %v", ErrMissingSubject, e)
+	}
+}
+
+func TestVerifyExpired(t *testing.T) {
+	configure(t)
+
+	ctx := context.Background()
+
+	signed, e := Create(ctx, &Claims{Subject: "user@example.com"}, TTL(-time.Minute))
+	if e != nil {
+		t.Fatal(e)
+	}
+
+	if _, e := Verify(ctx, signed, Leeway(0)); !(errors.Is(e, jwt.ErrTokenExpired)) {
+		t.Errorf("expected %v, got %v", jwt.ErrTokenExpired, e)
+	}
+
+	if _, e := Verify(ctx, signed, Leeway(2*time.Minute)); e != nil {
+		t.Errorf("expected leeway to tolerate expiration, got %v", e)
+	}
+}
+
+func TestVerifyKeyset(t *testing.T) {
+	private := configure(t)
+
+	ctx := context.Background()
+
+	signed, e := Create(ctx, &Claims{Subject: "user@example.com"})
+	if e != nil {
+		t.Fatal(e)
+	}
+
+	keyset := &JWKS{Keys: []JWK{NewJWK(&private.PublicKey)}}
+	if _, e := Verify(ctx, signed, Keyset(keyset)); e != nil {
+		t.Fatalf("unexpected error verifying against keyset: %v", e)
+	}
+
+	other := configure(t)
+	if _, e := Verify(ctx, signed, Keyset(&JWKS{Keys: []JWK{NewJWK(&other.PublicKey)}})); !(errors.Is(e, ErrUnknownKey)) {
+		t.Errorf("expected %v, got %v", ErrUnknownKey, e)
+	}
+}
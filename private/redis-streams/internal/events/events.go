@@ -0,0 +1,34 @@
+// Package events defines the typed representation(s) of the user-service stream's message(s).
+package events
+
+import (
+	"time"
+)
+
+// Registration is published by the user-service upon a new user's registration (type: "registration").
+type Registration struct {
+	Type     string    `json:"type"`
+	ID       int64     `json:"id"`
+	Email    string    `json:"email"`
+	Avatar   *string   `json:"avatar"`
+	Creation time.Time `json:"creation,omitempty"` // Creation is the account's creation timestamp (RFC 3339 or unix seconds).
+
+	Verification string `json:"verification,omitempty"` // Verification is the user's single-use email verification link; treat it as a secret.
+}
+
+// Deletion is published by the user-service once a soft-deleted user has been purged (type: "user.deleted"). Consumers must
+// erase their copies of the user's data.
+type Deletion struct {
+	Type     string    `json:"type"`
+	ID       int64     `json:"id"`
+	Email    string    `json:"email"`
+	Deletion time.Time `json:"deletion,omitempty"` // Deletion is the user's original soft-deletion timestamp.
+}
+
+// Update is published by the user-service upon a change to a user's profile (type: "user.updated").
+type Update struct {
+	Type         string    `json:"type"`
+	ID           int64     `json:"id"`
+	Fields       []string  `json:"fields"` // Fields lists the changed attribute(s), published as a comma-separated string.
+	Modification time.Time `json:"modification,omitempty"`
+}
@@ -0,0 +1,139 @@
+package reflection
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"runtime"
+	"strings"
+	"sync"
+)
+
+// plans caches a *plan per [signature]; entries are never evicted, as the set of struct types in a program is fixed.
+var plans sync.Map
+
+// leaves caches [leaf] result(s) per [reflect.Type].
+var leaves sync.Map
+
+// signature identifies a struct type's [plan] under a specific [Options] configuration.
+type signature struct {
+	typ         reflect.Type
+	fingerprint string
+}
+
+// fingerprint returns a comparable representation of the [Options] naming configuration, and whether it uniquely identifies it.
+// [Options.Naming] is identified by its code pointer, which is only unique to package-level function(s): method values
+// (e.g. Naming(converter.ToCamel)) and closures share code across receivers and captured state, so they aren't cached.
+func (o *Options) fingerprint() (string, bool) {
+	var naming uintptr
+	if o.Naming != nil {
+		naming = reflect.ValueOf(o.Naming).Pointer()
+		if !(static(naming)) {
+			return "", false
+		}
+	}
+
+	return fmt.Sprintf("%s|%x", strings.Join(o.Tags, ","), naming), true
+}
+
+// closure matches the runtime name(s) of function literals, e.g. "main.main.func1" or "main.init.func2.1".
+var closure = regexp.MustCompile(`\.func\d+(\.\d+)*$`)
+
+// static reports whether the code pointer belongs to a package-level function or method expression - as opposed to a
+// method value, whose runtime name carries a "-fm" suffix, or a closure.
+func static(pc uintptr) bool {
+	function := runtime.FuncForPC(pc)
+	if function == nil {
+		return false
+	}
+
+	name := function.Name()
+
+	return !(strings.HasSuffix(name, "-fm")) && !(closure.MatchString(name))
+}
+
+// planned represents a struct field's precomputed conversion metadata.
+type planned struct {
+	index     int
+	name      string
+	omitempty bool
+	embedded  bool // embedded is true for anonymous struct (pointer) fields whose fields are promoted; see [embedded].
+	direct    bool // direct is true if the field's value is used as-is: scalar kinds and types that serialize themselves; see [leaf].
+}
+
+// plan represents a struct type's precomputed field metadata for both [Convert] and [Decode].
+type plan struct {
+	fields []planned // fields are the struct's direct, non-skipped field(s) in declaration order.
+
+	lookup  map[string]*destination // lookup maps normalized key(s) to their [Decode] destination.
+	ordered []*destination          // ordered lists the [Decode] destination(s) in precedence order.
+}
+
+// compile returns the cached [plan] for the struct type, building it on first use. Concurrent first calls may each build a
+// plan, but all callers receive the same stored instance. Plans for an uncacheable naming strategy (see [Options.fingerprint])
+// are rebuilt on every call.
+func compile(typ reflect.Type, o *Options) *plan {
+	fingerprint, stable := o.fingerprint()
+
+	key := signature{typ: typ, fingerprint: fingerprint}
+	if stable {
+		if cached, ok := plans.Load(key); ok {
+			return cached.(*plan)
+		}
+	}
+
+	p := &plan{}
+	for i := 0; i < typ.NumField(); i++ {
+		structure := typ.Field(i)
+
+		if embedded(structure, o) {
+			p.fields = append(p.fields, planned{index: i, embedded: true})
+			continue
+		}
+
+		directive := resolve(structure, o)
+		if directive.skip {
+			continue
+		}
+
+		p.fields = append(p.fields, planned{index: i, name: directive.name, omitempty: directive.omitempty, direct: direct(structure.Type)})
+	}
+
+	p.lookup, p.ordered = destinations(typ, o)
+
+	if !(stable) {
+		return p
+	}
+
+	actual, _ := plans.LoadOrStore(key, p)
+
+	return actual.(*plan)
+}
+
+// direct reports whether value(s) of the type bypass the walker entirely.
+func direct(typ reflect.Type) bool {
+	switch typ.Kind() {
+	case reflect.Bool, reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64, reflect.Complex64, reflect.Complex128, reflect.String:
+		return true
+	case reflect.Struct, reflect.Array, reflect.Map:
+		return cacheable(typ)
+	}
+
+	// --> pointers and interfaces are dereferenced first; slices are checked for []byte by the walker
+	return false
+}
+
+// cacheable is the memoized variant of [leaf].
+func cacheable(typ reflect.Type) bool {
+	if cached, ok := leaves.Load(typ); ok {
+		return cached.(bool)
+	}
+
+	result := leaf(typ)
+
+	leaves.Store(typ, result)
+
+	return result
+}
@@ -0,0 +1,235 @@
+package reflection
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"time"
+)
+
+var ErrTypeMismatch = errors.New("type mismatch")
+
+// Change represents a single field's difference between two value(s).
+type Change struct {
+	Path string      `json:"path"` // Path is the dot-delimited field path, named according to the [Options].
+	Old  interface{} `json:"old"`
+	New  interface{} `json:"new"`
+}
+
+// Diff compares two value(s) of the same struct type, returning a [Change] for every differing field - sorted by path.
+// Nested struct(s) and maps are compared field-by-field; all other value(s), including slices, are compared as a whole.
+// Fields omitted by "omitempty" are reported as nil.
+func Diff(old, new interface{}, settings ...Variadic) ([]Change, error) {
+	if reflect.TypeOf(old) != reflect.TypeOf(new) {
+		return nil, fmt.Errorf("%w: %T and %T", ErrTypeMismatch, old, new)
+	}
+
+	before, e := Convert(old, settings...)
+	if e != nil {
+		return nil, e
+	}
+
+	after, e := Convert(new, settings...)
+	if e != nil {
+		return nil, e
+	}
+
+	var changes []Change
+
+	compare("", before, after, &changes)
+
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].Path < changes[j].Path
+	})
+
+	return changes, nil
+}
+
+// compare recursively appends the difference(s) between two converted map(s) to changes.
+func compare(prefix string, before, after map[string]interface{}, changes *[]Change) {
+	keys := make(map[string]struct{}, len(before)+len(after))
+	for key := range before {
+		keys[key] = struct{}{}
+	}
+
+	for key := range after {
+		keys[key] = struct{}{}
+	}
+
+	for key := range keys {
+		path := key
+		if prefix != "" {
+			path = prefix + "." + key
+		}
+
+		old, new := before[key], after[key]
+
+		previous, ok := old.(map[string]interface{})
+		current, valid := new.(map[string]interface{})
+		if ok && valid {
+			compare(path, previous, current, changes)
+			continue
+		}
+
+		if !(equal(old, new)) {
+			*changes = append(*changes, Change{Path: path, Old: old, New: new})
+		}
+	}
+}
+
+// equal reports whether two converted value(s) are equivalent. Timestamps are compared by instant, ignoring location and
+// monotonic clock reading(s).
+func equal(a, b interface{}) bool {
+	if x, ok := a.(time.Time); ok {
+		if y, ok := b.(time.Time); ok {
+			return x.Equal(y)
+		}
+	}
+
+	return reflect.DeepEqual(a, b)
+}
+
+// Apply applies a JSON Merge Patch (RFC 7396) style map to the struct pointed to by target, using the [Options] naming
+// rule(s) and the same key matching as [Decode]:
+//
+//   - A nil value resets the field to its zero value (or deletes the key, for maps).
+//   - A map value recursively merges into a struct, struct pointer or map field.
+//   - Any other value replaces the field, provided its kind matches the field's type.
+//
+// Unlike [Decode], Apply doesn't coerce between strings, bools and numbers: {"port": "8080"} is invalid for an integer
+// field, as is {"name": 1} for a string field. Numeric value(s) still convert between numeric type(s) when lossless, and
+// strings still decode into timestamps, durations and [encoding.TextUnmarshaler] implementations.
+//
+// Apply is atomic: if any key is unknown or any value is invalid, a [DecodeError] is returned and target is left unmodified.
+func Apply(target interface{}, patch map[string]interface{}, settings ...Variadic) error {
+	o := options()
+	for _, option := range settings {
+		option(o)
+	}
+
+	v := reflect.ValueOf(target)
+	if v.Kind() != reflect.Pointer || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("%w: patch target must be a non-nil struct pointer, received %T", ErrNotStruct, target)
+	}
+
+	// --> patch a copy; pointers and maps are cloned before being written to, so the original is never shared
+	working := reflect.New(v.Elem().Type()).Elem()
+	working.Set(v.Elem())
+
+	d := &decoder{options: o, result: &DecodeError{}, strict: true}
+
+	d.merge(patch, working)
+
+	if !(d.result.empty()) {
+		sort.Strings(d.result.Unknown)
+
+		return d.result
+	}
+
+	v.Elem().Set(working)
+
+	return nil
+}
+
+// merge applies the patch's key(s) to the struct value.
+func (d *decoder) merge(patch map[string]interface{}, v reflect.Value) {
+	p := compile(v.Type(), d.options)
+
+	for key, value := range patch {
+		target, ok := p.lookup[normalize(key)]
+		if !(ok) {
+			d.result.Unknown = append(d.result.Unknown, d.field(key))
+			continue
+		}
+
+		d.path = append(d.path, target.name)
+
+		d.patch(value, clone(v, target.index))
+
+		d.path = d.path[:len(d.path)-1]
+	}
+}
+
+// patch applies a single patch value to v.
+func (d *decoder) patch(value interface{}, v reflect.Value) {
+	if value == nil {
+		v.Set(reflect.Zero(v.Type()))
+		return
+	}
+
+	if v.Kind() == reflect.Pointer {
+		replacement := reflect.New(v.Type().Elem())
+		if !(v.IsNil()) {
+			replacement.Elem().Set(v.Elem())
+		}
+
+		v.Set(replacement)
+
+		d.patch(value, replacement.Elem())
+
+		return
+	}
+
+	object, ok := value.(map[string]interface{})
+	if !(ok) {
+		d.value(value, v)
+		return
+	}
+
+	switch {
+	case v.Kind() == reflect.Struct && !(cacheable(v.Type())):
+		d.merge(object, v)
+	case v.Kind() == reflect.Map && v.Type().Key().Kind() == reflect.String:
+		replacement := reflect.MakeMapWithSize(v.Type(), v.Len()+len(object))
+
+		iterator := v.MapRange()
+		for iterator.Next() {
+			replacement.SetMapIndex(iterator.Key(), iterator.Value())
+		}
+
+		for key, value := range object {
+			index := reflect.ValueOf(key).Convert(v.Type().Key())
+			if value == nil {
+				replacement.SetMapIndex(index, reflect.Value{})
+				continue
+			}
+
+			element := reflect.New(v.Type().Elem()).Elem()
+			if existing := replacement.MapIndex(index); existing.IsValid() {
+				element.Set(existing)
+			}
+
+			d.path = append(d.path, key)
+			d.patch(value, element)
+			d.path = d.path[:len(d.path)-1]
+
+			replacement.SetMapIndex(index, element)
+		}
+
+		v.Set(replacement)
+	default:
+		d.value(value, v)
+	}
+}
+
+// clone is the copy-on-write variant of [traverse]: embedded struct pointer(s) along the path are replaced by copies rather
+// than written through.
+func clone(v reflect.Value, index []int) reflect.Value {
+	for position, i := range index {
+		if position > 0 && v.Kind() == reflect.Pointer {
+			replacement := reflect.New(v.Type().Elem())
+			if !(v.IsNil()) {
+				replacement.Elem().Set(v.Elem())
+			}
+
+			v.Set(replacement)
+
+			v = replacement.Elem()
+		}
+
+		v = v.Field(i)
+	}
+
+	return v
+}
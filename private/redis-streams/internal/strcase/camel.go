@@ -0,0 +1,112 @@
+/*
+ * The MIT License (MIT)
+ *
+ * Copyright (c) 2015 Ian Coleman
+ * Copyright (c) 2018 Ma_124, <github.com/Ma124>
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, Subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or Substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package strcase
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Converts a string to CamelCase
+func (c *Converter) toCamelInitCase(s string, initCase bool) string {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return s
+	}
+	if a, hasAcronym := c.acronyms.Load(s); hasAcronym {
+		return toCamelAcronym(a.(string), initCase)
+	}
+
+	n := strings.Builder{}
+	n.Grow(len(s))
+	first := true
+	for _, t := range c.split(s, "") {
+		// non-alphanumeric literals are dropped
+		if t.literal {
+			continue
+		}
+
+		if first && !initCase {
+			n.WriteString(strings.ToLower(string(t.text)))
+		} else {
+			n.WriteString(c.capitalize(t.text))
+		}
+		first = false
+	}
+	return n.String()
+}
+
+// Converts a configured acronym's value to CamelCase, preserving its casing
+func toCamelAcronym(s string, initCase bool) string {
+	n := strings.Builder{}
+	n.Grow(len(s))
+	capNext := initCase
+	for i, v := range []rune(s) {
+		vIsCap := unicode.IsUpper(v)
+		vIsLow := unicode.IsLower(v)
+		if capNext {
+			if vIsLow {
+				v = unicode.ToTitle(v)
+			}
+		} else if i == 0 {
+			if vIsCap {
+				v = unicode.ToLower(v)
+			}
+		}
+
+		// letters without case (e.g. CJK ideographs) are kept as-is
+		if vIsCap || vIsLow || unicode.IsLetter(v) {
+			n.WriteRune(v)
+			capNext = false
+		} else if vIsNum := unicode.IsDigit(v); vIsNum {
+			n.WriteRune(v)
+			capNext = true
+		} else {
+			capNext = v == '_' || v == ' ' || v == '-' || v == '.'
+		}
+	}
+	return n.String()
+}
+
+// ToCamel converts a string to CamelCase
+func (c *Converter) ToCamel(s string) string {
+	return c.toCamelInitCase(s, true)
+}
+
+// ToLowerCamel converts a string to lowerCamelCase
+func (c *Converter) ToLowerCamel(s string) string {
+	return c.toCamelInitCase(s, false)
+}
+
+// ToCamel converts a string to CamelCase using the default [Converter]
+func ToCamel(s string) string {
+	return standard.ToCamel(s)
+}
+
+// ToLowerCamel converts a string to lowerCamelCase using the default [Converter]
+func ToLowerCamel(s string) string {
+	return standard.ToLowerCamel(s)
+}
@@ -0,0 +1,17 @@
+// Package strcase converts strings to various cases. See the conversion table below:
+//   | Function                        | Result             |
+//   |---------------------------------|--------------------|
+//   | ToSnake(s)                      | any_kind_of_string |
+//   | ToScreamingSnake(s)             | ANY_KIND_OF_STRING |
+//   | ToKebab(s)                      | any-kind-of-string |
+//   | ToScreamingKebab(s)             | ANY-KIND-OF-STRING |
+//   | ToDelimited(s, '.')             | any.kind.of.string |
+//   | ToScreamingDelimited(s, '.')    | ANY.KIND.OF.STRING |
+//   | ToCamel(s)                      | AnyKindOfString    |
+//   | ToLowerCamel(s)                 | anyKindOfString    |
+//   | ToTitle(s)                      | Any Kind Of String |
+//   | ToTrain(s)                      | Any-Kind-Of-String |
+//   | ToDot(s)                        | any.kind.of.string |
+//   | ToPath(s)                       | any/kind/of/string |
+//   | ToGoIdentifier(s, false)        | anyKindOfString    |
+package strcase
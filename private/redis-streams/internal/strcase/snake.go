@@ -0,0 +1,122 @@
+/*
+ * The MIT License (MIT)
+ *
+ * Copyright (c) 2015 Ian Coleman
+ * Copyright (c) 2018 Ma_124, <github.com/Ma124>
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, Subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or Substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package strcase
+
+import (
+	"strings"
+)
+
+// ToSnake converts a string to snake_case
+func (c *Converter) ToSnake(s string) string {
+	return c.ToDelimited(s, '_')
+}
+
+// ToSnakeWithIgnore converts a string to snake_case, keeping rune(s) in ignore as-is
+func (c *Converter) ToSnakeWithIgnore(s string, ignore string) string {
+	return c.ToScreamingDelimited(s, '_', ignore, false)
+}
+
+// ToScreamingSnake converts a string to SCREAMING_SNAKE_CASE
+func (c *Converter) ToScreamingSnake(s string) string {
+	return c.ToScreamingDelimited(s, '_', "", true)
+}
+
+// ToKebab converts a string to kebab-case
+func (c *Converter) ToKebab(s string) string {
+	return c.ToDelimited(s, '-')
+}
+
+// ToScreamingKebab converts a string to SCREAMING-KEBAB-CASE
+func (c *Converter) ToScreamingKebab(s string) string {
+	return c.ToScreamingDelimited(s, '-', "", true)
+}
+
+// ToDelimited converts a string to delimited.snake.case
+// (in this case `delimiter = '.'`)
+func (c *Converter) ToDelimited(s string, delimiter uint8) string {
+	return c.ToScreamingDelimited(s, delimiter, "", false)
+}
+
+// ToScreamingDelimited converts a string to SCREAMING.DELIMITED.SNAKE.CASE
+// (in this case `delimiter = '.'; screaming = true`)
+// or delimited.snake.case
+// (in this case `delimiter = '.'; screaming = false`)
+func (c *Converter) ToScreamingDelimited(s string, delimiter uint8, ignore string, screaming bool) string {
+	n := strings.Builder{}
+	n.Grow(len(s) + 2) // nominal 2 bytes of extra space for inserted delimiters
+	tokens := c.split(s, ignore)
+	for i, t := range tokens {
+		// words are delimited from one another, while literals are only delimited where the input had a separator
+		if i > 0 && (t.separated || (!t.literal && !tokens[i-1].literal)) {
+			n.WriteByte(delimiter)
+		}
+
+		switch {
+		case t.literal:
+			n.WriteString(string(t.text))
+		case screaming:
+			n.WriteString(strings.ToUpper(string(t.text)))
+		default:
+			n.WriteString(strings.ToLower(string(t.text)))
+		}
+	}
+
+	return n.String()
+}
+
+// ToSnake converts a string to snake_case using the default [Converter]
+func ToSnake(s string) string {
+	return standard.ToSnake(s)
+}
+
+func ToSnakeWithIgnore(s string, ignore string) string {
+	return standard.ToSnakeWithIgnore(s, ignore)
+}
+
+// ToScreamingSnake converts a string to SCREAMING_SNAKE_CASE using the default [Converter]
+func ToScreamingSnake(s string) string {
+	return standard.ToScreamingSnake(s)
+}
+
+// ToKebab converts a string to kebab-case using the default [Converter]
+func ToKebab(s string) string {
+	return standard.ToKebab(s)
+}
+
+// ToScreamingKebab converts a string to SCREAMING-KEBAB-CASE using the default [Converter]
+func ToScreamingKebab(s string) string {
+	return standard.ToScreamingKebab(s)
+}
+
+// ToDelimited converts a string to delimited.snake.case using the default [Converter]
+func ToDelimited(s string, delimiter uint8) string {
+	return standard.ToDelimited(s, delimiter)
+}
+
+// ToScreamingDelimited converts a string to SCREAMING.DELIMITED.SNAKE.CASE or delimited.snake.case using the default [Converter]
+func ToScreamingDelimited(s string, delimiter uint8, ignore string, screaming bool) string {
+	return standard.ToScreamingDelimited(s, delimiter, ignore, screaming)
+}
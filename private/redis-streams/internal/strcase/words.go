@@ -0,0 +1,151 @@
+package strcase
+
+import (
+	"strings"
+	"unicode"
+)
+
+// token is a single unit produced by [split]: either a word, or a literal rune (an ignored or non-alphanumeric character)
+// that's emitted verbatim.
+type token struct {
+	text      []rune
+	literal   bool
+	separated bool // separated is true if one or more separators (space, underscore, hyphen or dot) preceded the token.
+}
+
+// separator reports whether the rune delimits words.
+func separator(r rune) bool {
+	return r == ' ' || r == '_' || r == '-' || r == '.'
+}
+
+// split tokenizes s into words and literal(s). Rune(s) in ignore are always literals, even if they're separators.
+func (c *Converter) split(s string, ignore string) []token {
+	runes := []rune(strings.TrimSpace(s))
+
+	var tokens []token
+
+	separated := false
+	for i := 0; i < len(runes); {
+		r := runes[i]
+
+		switch {
+		case strings.ContainsRune(ignore, r):
+		case separator(r):
+			separated = true
+			i++
+
+			continue
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			j := i
+			for j < len(runes) && (unicode.IsLetter(runes[j]) || unicode.IsDigit(runes[j])) && !(strings.ContainsRune(ignore, runes[j])) {
+				j++
+			}
+
+			for _, word := range c.chunk(runes[i:j]) {
+				tokens = append(tokens, token{text: word, separated: separated})
+				separated = false
+			}
+
+			i = j
+
+			continue
+		}
+
+		tokens = append(tokens, token{text: runes[i : i+1], literal: true, separated: separated})
+		separated = false
+		i++
+	}
+
+	return tokens
+}
+
+// chunk splits a run of letters and digits into words at case and digit transitions, keeping initialisms whole; e.g.
+// "JSONAPIClient2" -> "JSON", "API", "Client", "2".
+func (c *Converter) chunk(runes []rune) [][]rune {
+	var words [][]rune
+
+	for i := 0; i < len(runes); {
+		if length := c.initialisms.exact(runes, i); length > 0 {
+			words = append(words, runes[i:i+length])
+			i += length
+
+			continue
+		}
+
+		j := i + 1
+
+		switch r := runes[i]; {
+		case unicode.IsDigit(r):
+			for j < len(runes) && unicode.IsDigit(runes[j]) {
+				j++
+			}
+		case unicode.IsUpper(r) || unicode.IsTitle(r):
+			for j < len(runes) && unicode.IsUpper(runes[j]) {
+				j++
+			}
+
+			if j < len(runes) && lower(runes[j]) {
+				// --> a single capital followed by lowercase letter(s), e.g. "User"
+				if j-i == 1 {
+					for j < len(runes) && lower(runes[j]) {
+						j++
+					}
+
+					break
+				}
+
+				// --> plural initialisms, e.g. "IDs"
+				if segments := c.initialisms.segment(runes[i:j]); segments != nil && runes[j] == 's' && (j+1 == len(runes) || !(lower(runes[j+1]))) {
+					last := segments[len(segments)-1]
+					segments[len(segments)-1] = runes[j-len(last) : j+1]
+
+					words = append(words, segments...)
+					i = j + 1
+
+					continue
+				}
+
+				// --> otherwise the run's last capital starts the next word, e.g. "HTTPServer"
+				j--
+			}
+
+			if segments := c.initialisms.segment(runes[i:j]); len(segments) > 1 {
+				words = append(words, segments...)
+				i = j
+
+				continue
+			}
+		default:
+			for j < len(runes) && lower(runes[j]) {
+				j++
+			}
+		}
+
+		words = append(words, runes[i:j])
+		i = j
+	}
+
+	return words
+}
+
+// lower reports whether the rune is a lowercase letter, or a letter without case (e.g. CJK ideographs), which
+// otherwise behaves like a lowercase letter when detecting word boundaries.
+func lower(r rune) bool {
+	return unicode.IsLower(r) || (unicode.IsLetter(r) && !unicode.IsUpper(r) && !unicode.IsTitle(r))
+}
+
+// capitalize returns the word's title-cased form - or, for initialisms, its canonical spelling. A plural initialism keeps
+// its lowercase "s", e.g. "ids" -> "IDs", so [chunk]'s plural words survive a round trip.
+func (c *Converter) capitalize(word []rune) string {
+	if canonical, ok := c.initialisms.canonical(string(word)); ok {
+		return canonical
+	}
+
+	if last := len(word) - 1; last > 0 && (word[last] == 's' || word[last] == 'S') {
+		if canonical, ok := c.initialisms.canonical(string(word[:last])); ok {
+			return canonical + "s"
+		}
+	}
+
+	return string(unicode.ToTitle(word[0])) + strings.ToLower(string(word[1:]))
+}
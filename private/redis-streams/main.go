@@ -18,13 +18,24 @@ import (
 	"github.com/redis/go-redis/v9"
 	"github.com/x-ethr/levels"
 
+	"redis-streams/internal/events"
 	"redis-streams/internal/exception"
+	"redis-streams/internal/reflection"
 )
 
 const count = 1
 const stream = "user-service"
 const group = "poller"
 
+// idle is the duration a pending message must go unacknowledged before another consumer may reclaim it.
+var idle = time.Minute
+
+// attempts is the number of delivery attempt(s) after which a pending message is moved to the dead-letter stream.
+const attempts = 5
+
+// dead is the stream unprocessable message(s) are moved to for manual inspection.
+const dead = stream + ":dead-letter"
+
 var consumer string = os.Getenv("CONSUMER")
 var level string = os.Getenv("LOG_LEVEL")
 var l = slog.LevelDebug
@@ -34,7 +45,11 @@ var ctx, cancel = context.WithCancel(context.Background())
 func init() {
 	flag.StringVar(&level, "log-level", "DEBUG", "runtime logging log-level")
 	flag.StringVar(&consumer, "consumer", consumer, "unique consumer name")
+}
 
+// configure parses and validates the command-line flag(s) before initializing the logger. It's called from main rather than
+// init so test binaries, which parse their own flags, can import the package.
+func configure() {
 	flag.Parse()
 
 	switch {
@@ -72,6 +87,8 @@ func logger() {
 }
 
 func main() {
+	configure()
+
 	slog.Log(ctx, slog.LevelInfo, "Initializing Poller ...")
 
 	client := redis.NewClient(&redis.Options{Addr: "localhost:6379"})
@@ -111,7 +128,18 @@ func main() {
 func Poll(ctx context.Context, client *redis.Client) {
 	read := &redis.XReadGroupArgs{Group: group, Streams: []string{stream, ">"}, Consumer: consumer, Count: count, Block: time.Second * 5, NoAck: false}
 
+	var reclaimed time.Time
+
 	for {
+		// --> failed message(s), and those held by crashed consumer(s), would otherwise remain pending indefinitely
+		if time.Since(reclaimed) >= idle {
+			if e := Reclaim(ctx, client); e != nil && !(errors.Is(e, context.Canceled)) {
+				slog.WarnContext(ctx, "Unable to Reclaim Stale Pending Message(s)", slog.String("error", e.Error()))
+			}
+
+			reclaimed = time.Now()
+		}
+
 		result, err := client.XReadGroup(ctx, read).Result()
 
 		if err != nil {
@@ -157,44 +185,147 @@ func Poll(ctx context.Context, client *redis.Client) {
 
 		slog.Log(ctx, levels.Trace, "Message Data", slog.Any("message", message))
 
+		// --> unprocessed message(s) remain in the group's pending entries list until reclaimed (see Reclaim)
+		if e := Process(ctx, message); e != nil {
+			slog.ErrorContext(ctx, "Unable to Process Message", slog.String("id", message.ID), slog.String("error", e.Error()))
+
+			continue
+		}
+
 		time.Sleep(time.Second * 5)
 
-		slog.Log(ctx, levels.Trace, "Claiming Message (XAck)", slog.Any("id", message.ID))
+		Acknowledge(ctx, client, message.ID)
+	}
+}
 
-		err = client.XAck(ctx, stream, group, message.ID).Err()
-		if err != nil {
-			slog.ErrorContext(ctx, "Fatal Error Attempting to Claim Message", slog.String("id", message.ID), slog.String("error", e.Error()))
-			panic(e)
+// Reclaim claims the group's stale pending message(s) - those unacknowledged for at least [idle] - and re-processes them.
+// Message(s) already delivered [attempts] time(s) are instead moved to the [dead] stream.
+func Reclaim(ctx context.Context, client *redis.Client) error {
+	pending, e := client.XPendingExt(ctx, &redis.XPendingExtArgs{Stream: stream, Group: group, Idle: idle, Start: "-", End: "+", Count: 100}).Result()
+	if e != nil {
+		return e
+	}
+
+	for _, entry := range pending {
+		// --> claiming re-checks the idle time, so concurrent consumer(s) never claim the same entry
+		messages, e := client.XClaim(ctx, &redis.XClaimArgs{Stream: stream, Group: group, Consumer: consumer, MinIdle: idle, Messages: []string{entry.ID}}).Result()
+		if e != nil {
+			return e
 		}
 
-		slog.Log(ctx, levels.Trace, "Deleting Message (XDel)", slog.Any("id", message.ID))
+		if len(messages) == 0 {
+			continue
+		}
 
-		err = client.XDel(ctx, stream, message.ID).Err()
-		if err != nil {
-			slog.ErrorContext(ctx, "Fatal Error has Occurred Attempting to Delete Message", slog.String("id", message.ID), slog.String("error", e.Error()))
-			panic(e)
+		message := &messages[0]
+
+		if entry.RetryCount >= attempts {
+			if e := DeadLetter(ctx, client, message, entry.RetryCount); e != nil {
+				return e
+			}
+
+			continue
+		}
+
+		slog.InfoContext(ctx, "Reclaimed Stale Pending Message", slog.String("id", message.ID), slog.String("previous-consumer", entry.Consumer), slog.Int64("deliveries", entry.RetryCount))
+
+		if e := Process(ctx, message); e != nil {
+			slog.ErrorContext(ctx, "Unable to Process Message", slog.String("id", message.ID), slog.String("error", e.Error()))
+
+			continue
 		}
+
+		Acknowledge(ctx, client, message.ID)
+	}
+
+	return nil
+}
+
+// DeadLetter atomically moves the message to the [dead] stream, retaining its original identifier and delivery count.
+func DeadLetter(ctx context.Context, client *redis.Client, message *redis.XMessage, deliveries int64) error {
+	values := make(map[string]interface{}, len(message.Values)+2)
+	for key, value := range message.Values {
+		values[key] = value
+	}
+
+	values["dead-letter-id"] = message.ID
+	values["dead-letter-deliveries"] = deliveries
+
+	_, e := client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.XAdd(ctx, &redis.XAddArgs{Stream: dead, Values: values})
+		pipe.XAck(ctx, stream, group, message.ID)
+		pipe.XDel(ctx, stream, message.ID)
+
+		return nil
+	})
+
+	if e != nil {
+		return e
+	}
+
+	slog.WarnContext(ctx, "Moved Unprocessable Message to Dead-Letter Stream", slog.String("id", message.ID), slog.String("dead-letter", dead), slog.Int64("deliveries", deliveries))
+
+	return nil
+}
+
+// Acknowledge acknowledges (XAck) and deletes (XDel) a successfully processed message.
+func Acknowledge(ctx context.Context, client *redis.Client, id string) {
+	slog.Log(ctx, levels.Trace, "Claiming Message (XAck)", slog.Any("id", id))
+
+	if e := client.XAck(ctx, stream, group, id).Err(); e != nil {
+		slog.ErrorContext(ctx, "Fatal Error Attempting to Claim Message", slog.String("id", id), slog.String("error", e.Error()))
+		panic(e)
+	}
+
+	slog.Log(ctx, levels.Trace, "Deleting Message (XDel)", slog.Any("id", id))
+
+	if e := client.XDel(ctx, stream, id).Err(); e != nil {
+		slog.ErrorContext(ctx, "Fatal Error has Occurred Attempting to Delete Message", slog.String("id", id), slog.String("error", e.Error()))
+		panic(e)
 	}
 }
 
 func Process(ctx context.Context, message *redis.XMessage) error {
 	slog.Log(ctx, levels.Trace, "Processing Message", slog.String("id", message.ID))
 
-	var target string
-	if v, ok := message.Values["type"]; ok {
-		target = v.(string)
-	}
+	target, _ := message.Values["type"].(string)
 
+	// --> failure(s) are logged by the caller, which leaves the message pending
 	if target == "" {
-		e := errors.New("key \"type\" not found in stream message")
-		slog.ErrorContext(ctx, "Key (\"type\") Not Found in Stream Message", slog.String("error", e.Error()))
-		return e
+		return errors.New("key \"type\" not found in stream message")
 	}
 
 	switch target {
 	case "registration": // new user registration
+		var event events.Registration
+		if e := reflection.Decode(message.Values, &event); e != nil {
+			return fmt.Errorf("unable to decode registration event: %w", e)
+		}
 
+		slog.InfoContext(ctx, "Processing Registration Event", slog.Int64("user", event.ID), slog.String("email", event.Email))
+
+		return nil
+	case "user.deleted": // purged user; erase all derived data
+		var event events.Deletion
+		if e := reflection.Decode(message.Values, &event); e != nil {
+			return fmt.Errorf("unable to decode deletion event: %w", e)
+		}
+
+		slog.InfoContext(ctx, "Processing Deletion Event", slog.Int64("user", event.ID), slog.String("email", event.Email))
+
+		return nil
+	case "user.updated": // profile update
+		var event events.Update
+		if e := reflection.Decode(message.Values, &event); e != nil {
+			return fmt.Errorf("unable to decode update event: %w", e)
+		}
+
+		slog.InfoContext(ctx, "Processing Update Event", slog.Int64("user", event.ID), slog.Any("fields", event.Fields))
+
+		return nil
 	}
+
+	return fmt.Errorf("unsupported stream message type: %q", target)
 }
 
 // Interrupt is a graceful interrupt + signal handler for a redis consumer poller.
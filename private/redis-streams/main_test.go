@@ -0,0 +1,196 @@
+package main
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// setup connects to a disposable redis instance, specified by the "REDIS_TEST_ADDRESS" environment variable, and resets the
+// stream(s) and consumer group under test.
+func setup(t *testing.T) *redis.Client {
+	t.Helper()
+
+	address := os.Getenv("REDIS_TEST_ADDRESS")
+	if address == "" {
+		t.Skip("REDIS_TEST_ADDRESS not set")
+	}
+
+	ctx := context.Background()
+
+	client := redis.NewClient(&redis.Options{Addr: address})
+
+	reset := func() { client.Del(context.Background(), stream, dead) }
+
+	reset()
+
+	if e := client.XGroupCreateMkStream(ctx, stream, group, "0-0").Err(); e != nil {
+		t.Fatalf("unexpected error: %v", e)
+	}
+
+	name, duration := consumer, idle
+
+	consumer, idle = "test-consumer", 10*time.Millisecond
+
+	t.Cleanup(func() {
+		reset()
+
+		client.Close()
+
+		consumer, idle = name, duration
+	})
+
+	return client
+}
+
+// deliver adds the message(s) to the stream and reads them as the given consumer, leaving them pending.
+func deliver(t *testing.T, client *redis.Client, name string, messages ...map[string]interface{}) []string {
+	t.Helper()
+
+	ctx := context.Background()
+
+	identifiers := make([]string, 0, len(messages))
+	for _, values := range messages {
+		id, e := client.XAdd(ctx, &redis.XAddArgs{Stream: stream, Values: values}).Result()
+		if e != nil {
+			t.Fatalf("unexpected error: %v", e)
+		}
+
+		identifiers = append(identifiers, id)
+	}
+
+	if e := client.XReadGroup(ctx, &redis.XReadGroupArgs{Group: group, Streams: []string{stream, ">"}, Consumer: name, Count: int64(len(messages)), Block: -1}).Err(); e != nil {
+		t.Fatalf("unexpected error: %v", e)
+	}
+
+	return identifiers
+}
+
+func pending(t *testing.T, client *redis.Client) []redis.XPendingExt {
+	t.Helper()
+
+	entries, e := client.XPendingExt(context.Background(), &redis.XPendingExtArgs{Stream: stream, Group: group, Start: "-", End: "+", Count: 100}).Result()
+	if e != nil {
+		t.Fatalf("unexpected error: %v", e)
+	}
+
+	return entries
+}
+
+func TestReclaim(t *testing.T) {
+	client := setup(t)
+
+	ctx := context.Background()
+
+	identifiers := deliver(t, client, "crashed-consumer",
+		map[string]interface{}{"type": "registration", "id": "1", "email": "user@example.com"},
+		map[string]interface{}{"type": "unsupported"},
+	)
+
+	// --> neither message has been idle long enough to be reclaimed
+	idle = time.Hour
+
+	if e := Reclaim(ctx, client); e != nil {
+		t.Fatalf("unexpected error: %v", e)
+	}
+
+	if entries := pending(t, client); len(entries) != 2 || entries[0].Consumer != "crashed-consumer" {
+		t.Fatalf("expected 2 message(s) pending for the crashed consumer, got %+v", entries)
+	}
+
+	idle = 10 * time.Millisecond
+
+	time.Sleep(2 * idle)
+
+	if e := Reclaim(ctx, client); e != nil {
+		t.Fatalf("unexpected error: %v", e)
+	}
+
+	// --> the processable message is acknowledged and deleted; the unprocessable one remains pending, now claimed
+	entries := pending(t, client)
+	if len(entries) != 1 {
+		t.Fatalf("expected 1 pending message, got %+v", entries)
+	}
+
+	if entries[0].ID != identifiers[1] || entries[0].Consumer != consumer || entries[0].RetryCount != 2 {
+		t.Errorf("expected %s pending for %s after 2 deliveries, got %+v", identifiers[1], consumer, entries[0])
+	}
+
+	if total, e := client.XLen(ctx, stream).Result(); e != nil || total != 1 {
+		t.Errorf("expected 1 message in the stream, got %d (%v)", total, e)
+	}
+
+	// --> each subsequent reclaim re-delivers the message, until the attempts are exhausted
+	for deliveries := entries[0].RetryCount; deliveries <= attempts; deliveries++ {
+		time.Sleep(2 * idle)
+
+		if e := Reclaim(ctx, client); e != nil {
+			t.Fatalf("unexpected error: %v", e)
+		}
+	}
+
+	if entries := pending(t, client); len(entries) != 0 {
+		t.Fatalf("expected no pending message(s), got %+v", entries)
+	}
+
+	if total, e := client.XLen(ctx, stream).Result(); e != nil || total != 0 {
+		t.Errorf("expected an empty stream, got %d (%v)", total, e)
+	}
+
+	letters, e := client.XRange(ctx, dead, "-", "+").Result()
+	if e != nil {
+		t.Fatalf("unexpected error: %v", e)
+	}
+
+	if len(letters) != 1 {
+		t.Fatalf("expected 1 dead-letter message, got %d", len(letters))
+	}
+
+	if values := letters[0].Values; values["dead-letter-id"] != identifiers[1] || values["dead-letter-deliveries"] != "5" || values["type"] != "unsupported" {
+		t.Errorf("unexpected dead-letter message: %v", values)
+	}
+}
+
+func TestDeadLetter(t *testing.T) {
+	client := setup(t)
+
+	ctx := context.Background()
+
+	identifiers := deliver(t, client, consumer, map[string]interface{}{"type": "registration", "id": "invalid"})
+
+	messages, e := client.XRange(ctx, stream, identifiers[0], identifiers[0]).Result()
+	if e != nil || len(messages) != 1 {
+		t.Fatalf("unexpected range: %v (%v)", messages, e)
+	}
+
+	if e := DeadLetter(ctx, client, &messages[0], 3); e != nil {
+		t.Fatalf("unexpected error: %v", e)
+	}
+
+	if entries := pending(t, client); len(entries) != 0 {
+		t.Errorf("expected the message to be acknowledged, got %+v", entries)
+	}
+
+	if total, e := client.XLen(ctx, stream).Result(); e != nil || total != 0 {
+		t.Errorf("expected the message to be deleted, got %d (%v)", total, e)
+	}
+
+	letters, e := client.XRange(ctx, dead, "-", "+").Result()
+	if e != nil {
+		t.Fatalf("unexpected error: %v", e)
+	}
+
+	if len(letters) != 1 {
+		t.Fatalf("expected 1 dead-letter message, got %d", len(letters))
+	}
+
+	expected := map[string]interface{}{"type": "registration", "id": "invalid", "dead-letter-id": identifiers[0], "dead-letter-deliveries": "3"}
+	for key, value := range expected {
+		if letters[0].Values[key] != value {
+			t.Errorf("expected dead-letter %q = %v, got %v", key, value, letters[0].Values[key])
+		}
+	}
+}